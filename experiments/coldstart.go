@@ -0,0 +1,120 @@
+package experiments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// PodCounter retorna o número atual de pods da função avaliada
+type PodCounter func(ctx context.Context) (int, error)
+
+// ColdStartProbe mede a latência de cold start: espera a função escalar para
+// zero, envia uma única requisição e repete pelo número de iterações configurado
+type ColdStartProbe struct {
+	Parameters *parameters.BenchmarkParameters
+	PodCount   PodCounter
+}
+
+// NewColdStartProbe cria uma nova instância do ColdStartProbe
+func NewColdStartProbe(params *parameters.BenchmarkParameters, podCount PodCounter) *ColdStartProbe {
+	return &ColdStartProbe{
+		Parameters: params,
+		PodCount:   podCount,
+	}
+}
+
+// Run executa as sondagens e retorna uma amostra por iteração. Iterações em que
+// a função não chegou a zero pods dentro do timeout são registradas como tal.
+func (p *ColdStartProbe) Run(ctx context.Context) ([]metrics.ColdStartSample, error) {
+	timeout, _ := time.ParseDuration(p.Parameters.ColdStart.ScaleToZeroTimeout)
+	poll, _ := time.ParseDuration(p.Parameters.ColdStart.PollInterval)
+
+	engine, err := heyexec.NewLoadEngine(p.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]metrics.ColdStartSample, 0, p.Parameters.ColdStart.Iterations)
+	for i := 1; i <= p.Parameters.ColdStart.Iterations; i++ {
+		fmt.Printf("Sondagem %d/%d: aguardando a função %s escalar para zero...\n", i, p.Parameters.ColdStart.Iterations, p.Parameters.Function)
+
+		waitStart := time.Now()
+		scaledToZero, err := waitForPods(ctx, p.PodCount, poll, timeout, func(count int) bool { return count == 0 })
+		if err != nil {
+			return samples, err
+		}
+
+		sample := metrics.ColdStartSample{
+			Iteration:    i,
+			ScaledToZero: scaledToZero,
+			WaitedToZero: time.Since(waitStart),
+		}
+		if !scaledToZero {
+			fmt.Printf("Aviso: a função não escalou para zero em %s; a sondagem não mede um cold start\n", timeout)
+		}
+
+		sample.Timestamp = time.Now()
+		record := engine.Probe(ctx)
+		sample.Latency = record.ResponseTime
+		sample.StatusCode = record.StatusCode
+		sample.Error = record.Error
+
+		if count, err := p.PodCount(ctx); err == nil {
+			sample.PodsAfter = count
+		}
+
+		fmt.Printf("Sondagem %d/%d: %.4f s (status %d)\n", i, p.Parameters.ColdStart.Iterations, sample.Latency, sample.StatusCode)
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
+
+// waitForPods consulta a contagem de pods a cada intervalo até a condição ser
+// satisfeita ou o timeout expirar. Falhas pontuais na consulta são ignoradas, mas
+// uma fonte de métricas sem contagem de pods, ou uma falha na última leitura do
+// prazo, encerra a espera com erro. Uma função sem séries na fonte conta como zero pods.
+func waitForPods(ctx context.Context, podCount PodCounter, poll, timeout time.Duration, done func(int) bool) (bool, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		count, err := zeroIfNotFound(podCount(ctx))
+		if errors.Is(err, metrics.ErrNotSupported) {
+			return false, fmt.Errorf("pod count is required: %w", err)
+		}
+		if err == nil && done(count) {
+			return true, nil
+		}
+
+		if time.Now().After(deadline) {
+			// Com a última leitura falhando a contagem é desconhecida, e não zero,
+			// como em uma função sem séries na fonte
+			if err != nil {
+				return false, fmt.Errorf("pod count unavailable: %w", err)
+			}
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(poll):
+		}
+	}
+}
+
+// zeroIfNotFound trata uma função sem séries na fonte como zero pods. O exporter só
+// publica a contagem das funções que já observou com pods, então uma função que já
+// estava em zero quando ele iniciou não tem série até a primeira réplica.
+func zeroIfNotFound(count int, err error) (int, error) {
+	if errors.Is(err, metrics.ErrNotFound) {
+		return 0, nil
+	}
+	return count, err
+}
@@ -0,0 +1,113 @@
+package experiments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// ClusterCooldown espera, entre execuções, até a função voltar a um estado do
+// cluster: réplicas de antes da primeira execução, zero réplicas ou CPU ociosa
+type ClusterCooldown struct {
+	Parameters *parameters.BenchmarkParameters
+	Source     metrics.MetricsSource
+
+	baseline int
+}
+
+// NewCooldownPolicy cria a política de pausa configurada em cooldown.policy. Na
+// política baseline a contagem de réplicas de referência é lida agora, antes da
+// primeira execução. Retorna nil quando não há pausa.
+func NewCooldownPolicy(ctx context.Context, params *parameters.BenchmarkParameters, source metrics.MetricsSource) (heyexec.CooldownPolicy, error) {
+	if params.Execution <= 1 {
+		return nil, nil
+	}
+
+	if params.Cooldown.Policy == parameters.CooldownFixed {
+		return heyexec.NewFixedCooldown(params), nil
+	}
+
+	cooldown := &ClusterCooldown{
+		Parameters: params,
+		Source:     source,
+	}
+
+	if params.Cooldown.Policy == parameters.CooldownBaseline {
+		count, err := zeroIfNotFound(source.PodCount(ctx, params.Function))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read baseline pod count: %w", err)
+		}
+		cooldown.baseline = count
+	}
+
+	return cooldown, nil
+}
+
+// Wait consulta o cluster a cada poll_interval até o estado da política ou o timeout
+func (c *ClusterCooldown) Wait(ctx context.Context) heyexec.CooldownResult {
+	timeout, _ := time.ParseDuration(c.Parameters.Cooldown.Timeout)
+	poll, _ := time.ParseDuration(c.Parameters.Cooldown.PollInterval)
+	function := c.Parameters.Function
+
+	start := time.Now()
+	result := heyexec.CooldownResult{Policy: c.Parameters.Cooldown.Policy}
+
+	var reached bool
+	var err error
+	switch c.Parameters.Cooldown.Policy {
+	case parameters.CooldownBaseline:
+		reached, err = waitForPods(ctx, c.podCount, poll, timeout, func(count int) bool { return count <= c.baseline })
+	case parameters.CooldownScaleToZero:
+		reached, err = waitForPods(ctx, c.podCount, poll, timeout, func(count int) bool { return count == 0 })
+	case parameters.CooldownCPUIdle:
+		threshold := c.Parameters.Cooldown.CPUThreshold
+		reached, err = waitForSample(ctx, c.Source, function, poll, timeout, func(sample metrics.MetricSample) bool {
+			return sample.CPU < threshold
+		})
+	default:
+		err = fmt.Errorf("unsupported cooldown policy: %s", c.Parameters.Cooldown.Policy)
+	}
+
+	result.Waited = time.Since(start)
+	result.Reached = reached
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// podCount consulta a contagem de pods da função avaliada
+func (c *ClusterCooldown) podCount(ctx context.Context) (int, error) {
+	return c.Source.PodCount(ctx, c.Parameters.Function)
+}
+
+// waitForSample lê as métricas da função a cada intervalo até a condição ser
+// satisfeita ou o timeout expirar, com o mesmo tratamento de falhas de waitForPods
+func waitForSample(ctx context.Context, source metrics.MetricsSource, function string, poll, timeout time.Duration, done func(metrics.MetricSample) bool) (bool, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		sample, err := source.Sample(ctx, function)
+		if errors.Is(err, metrics.ErrNotSupported) {
+			return false, fmt.Errorf("metrics sample is required: %w", err)
+		}
+		if err == nil && done(sample) {
+			return true, nil
+		}
+
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(poll):
+		}
+	}
+}
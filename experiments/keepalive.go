@@ -0,0 +1,227 @@
+package experiments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// Requisições seguidas usadas para medir a latência de referência com a instância quente
+const warmupRequests = 5
+
+// KeepAliveSearch estima por quanto tempo a plataforma mantém uma instância ociosa:
+// envia uma requisição, fica sem tráfego por um intervalo crescente e envia outra,
+// classificando cada sondagem como quente ou fria pela latência e pela contagem de pods
+type KeepAliveSearch struct {
+	Parameters *parameters.BenchmarkParameters
+	PodCount   PodCounter
+
+	engine      *heyexec.LoadEngine
+	warmLatency float64
+}
+
+// NewKeepAliveSearch cria uma nova instância do KeepAliveSearch
+func NewKeepAliveSearch(params *parameters.BenchmarkParameters, podCount PodCounter) *KeepAliveSearch {
+	return &KeepAliveSearch{
+		Parameters: params,
+		PodCount:   podCount,
+	}
+}
+
+// Run mede a latência de referência e executa a estratégia de busca configurada.
+// Cada sondagem também reinicia o tempo ocioso da instância para a sondagem seguinte.
+func (s *KeepAliveSearch) Run(ctx context.Context) (*metrics.KeepAliveMetrics, error) {
+	keepAlive := s.Parameters.KeepAlive
+	minGap, _ := time.ParseDuration(keepAlive.MinGap)
+	maxGap, _ := time.ParseDuration(keepAlive.MaxGap)
+	resolution, _ := time.ParseDuration(keepAlive.Resolution)
+
+	engine, err := heyexec.NewLoadEngine(s.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	s.engine = engine
+
+	if err := s.measureWarmLatency(ctx); err != nil {
+		return nil, err
+	}
+	fmt.Printf("Latência de referência (instância quente): %.4f s\n", s.warmLatency)
+
+	var probes []metrics.KeepAliveProbe
+	if keepAlive.Strategy == parameters.KeepAliveBisection {
+		probes, err = s.bisection(ctx, minGap, maxGap, resolution)
+	} else {
+		probes, err = s.doubling(ctx, minGap, maxGap)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics.NewKeepAliveMetrics(s.Parameters.Platform, s.Parameters.Function, keepAlive.Strategy, s.warmLatency, probes), nil
+}
+
+// measureWarmLatency aquece a função e usa a mediana de requisições seguidas como referência
+func (s *KeepAliveSearch) measureWarmLatency(ctx context.Context) error {
+	// A primeira requisição pode ser um cold start e não entra na referência
+	s.engine.Probe(ctx)
+
+	var latencies []float64
+	for i := 0; i < warmupRequests; i++ {
+		record := s.engine.Probe(ctx)
+		if record.Error == "" && record.StatusCode < 400 {
+			latencies = append(latencies, record.ResponseTime)
+		}
+	}
+
+	if len(latencies) == 0 {
+		return fmt.Errorf("no successful request while warming up %s", s.Parameters.URL)
+	}
+
+	sort.Float64s(latencies)
+	s.warmLatency = heyexec.Percentile(latencies, 50)
+	return nil
+}
+
+// doubling dobra o intervalo ocioso a partir de minGap até a primeira sondagem fria ou maxGap
+func (s *KeepAliveSearch) doubling(ctx context.Context, minGap, maxGap time.Duration) ([]metrics.KeepAliveProbe, error) {
+	var probes []metrics.KeepAliveProbe
+
+	for gap := minGap; gap <= maxGap; gap *= 2 {
+		probe, err := s.probe(ctx, gap)
+		if err != nil {
+			return probes, err
+		}
+		probes = append(probes, probe)
+
+		if probe.Cold || probe.Error != "" {
+			break
+		}
+	}
+
+	return probes, nil
+}
+
+// bisection confirma que minGap é quente e maxGap é frio e estreita o intervalo
+// entre os dois até a resolução configurada
+func (s *KeepAliveSearch) bisection(ctx context.Context, minGap, maxGap, resolution time.Duration) ([]metrics.KeepAliveProbe, error) {
+	var probes []metrics.KeepAliveProbe
+
+	low, err := s.probe(ctx, minGap)
+	if err != nil {
+		return probes, err
+	}
+	probes = append(probes, low)
+	if low.Cold || low.Error != "" {
+		return probes, nil
+	}
+
+	high, err := s.probe(ctx, maxGap)
+	if err != nil {
+		return probes, err
+	}
+	probes = append(probes, high)
+	if !high.Cold || high.Error != "" {
+		return probes, nil
+	}
+
+	for lo, hi := minGap, maxGap; hi-lo > resolution; {
+		// Intervalos em segundos inteiros; sem um ponto novo entre os extremos a
+		// busca não avança mais
+		mid := (lo + (hi-lo)/2).Round(time.Second)
+		if mid <= lo || mid >= hi {
+			break
+		}
+
+		probe, err := s.probe(ctx, mid)
+		if err != nil {
+			return probes, err
+		}
+		probes = append(probes, probe)
+
+		if probe.Error != "" {
+			break
+		}
+		if probe.Cold {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return probes, nil
+}
+
+// probe aguarda o intervalo ocioso, envia uma requisição e a classifica
+func (s *KeepAliveSearch) probe(ctx context.Context, gap time.Duration) (metrics.KeepAliveProbe, error) {
+	fmt.Printf("Sondagem após %s sem tráfego...\n", gap)
+
+	select {
+	case <-ctx.Done():
+		return metrics.KeepAliveProbe{}, ctx.Err()
+	case <-time.After(gap):
+	}
+
+	probe := metrics.KeepAliveProbe{
+		Gap:        gap,
+		PodsBefore: s.podCount(ctx),
+		Timestamp:  time.Now(),
+	}
+
+	record := s.engine.Probe(ctx)
+	probe.Latency = record.ResponseTime
+	probe.StatusCode = record.StatusCode
+	probe.Error = record.Error
+	probe.PodsAfter = s.podCount(ctx)
+
+	if probe.Error == "" && probe.StatusCode >= 400 {
+		probe.Error = fmt.Sprintf("status %d", probe.StatusCode)
+	}
+	if probe.Error == "" {
+		s.classify(&probe)
+	}
+
+	state := "quente"
+	if probe.Cold {
+		state = "fria (" + probe.Reason + ")"
+	}
+	if probe.Error != "" {
+		state = "erro: " + probe.Error
+	}
+	fmt.Printf("Sondagem após %s: %.4f s, %s\n", gap, probe.Latency, state)
+
+	return probe, nil
+}
+
+// classify marca a sondagem como fria quando a função estava sem pods, quando um
+// novo pod surgiu ou quando a latência supera cold_factor vezes a de referência
+func (s *KeepAliveSearch) classify(probe *metrics.KeepAliveProbe) {
+	var reasons []string
+
+	if probe.PodsBefore == 0 {
+		reasons = append(reasons, "sem pods antes da sondagem")
+	}
+	if probe.PodsBefore >= 0 && probe.PodsAfter > probe.PodsBefore {
+		reasons = append(reasons, "novo pod")
+	}
+	if factor := probe.Latency / s.warmLatency; factor > s.Parameters.KeepAlive.ColdFactor {
+		reasons = append(reasons, fmt.Sprintf("latência %.1fx a de referência", factor))
+	}
+
+	probe.Cold = len(reasons) > 0
+	probe.Reason = strings.Join(reasons, ", ")
+}
+
+// podCount consulta a contagem de pods, retornando -1 quando ela não está disponível
+func (s *KeepAliveSearch) podCount(ctx context.Context) int {
+	count, err := zeroIfNotFound(s.PodCount(ctx))
+	if err != nil {
+		return -1
+	}
+	return count
+}
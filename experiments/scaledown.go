@@ -0,0 +1,25 @@
+package experiments
+
+import (
+	"context"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// WaitForScaleDown consulta a contagem de réplicas da função, a cada
+// metrics.sample_interval, até ela voltar a baseline ou a zero, ou até
+// metrics.scale_down_timeout. Com baseline negativo espera apenas por zero.
+// Retorna se o retorno foi observado.
+func WaitForScaleDown(ctx context.Context, params *parameters.BenchmarkParameters, source metrics.MetricsSource, baseline int) (bool, error) {
+	timeout, _ := time.ParseDuration(params.Metrics.ScaleDownTimeout)
+	poll, _ := time.ParseDuration(params.Metrics.SampleInterval)
+
+	podCount := func(ctx context.Context) (int, error) {
+		return source.PodCount(ctx, params.Function)
+	}
+	return waitForPods(ctx, podCount, poll, timeout, func(count int) bool {
+		return count == 0 || (baseline >= 0 && count <= baseline)
+	})
+}
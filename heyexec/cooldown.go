@@ -0,0 +1,58 @@
+package heyexec
+
+import (
+	"context"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// CooldownPolicy espera, antes de cada execução a partir da segunda, até o
+// cluster chegar ao estado desejado ou o timeout da política expirar
+type CooldownPolicy interface {
+	Wait(ctx context.Context) CooldownResult
+}
+
+// CooldownResult registra a pausa feita antes de uma execução
+type CooldownResult struct {
+	Policy  string
+	Waited  time.Duration
+	Reached bool   // estado desejado atingido antes do timeout
+	Error   string // falha na leitura do cluster, vazio em caso de sucesso
+}
+
+// FixedCooldown espera sempre a mesma duração
+type FixedCooldown struct {
+	Duration time.Duration
+}
+
+// Wait espera a duração configurada ou o cancelamento do contexto
+func (c FixedCooldown) Wait(ctx context.Context) CooldownResult {
+	start := time.Now()
+	result := CooldownResult{Policy: parameters.CooldownFixed, Reached: true}
+
+	select {
+	case <-ctx.Done():
+		result.Reached = false
+		result.Error = ctx.Err().Error()
+	case <-time.After(c.Duration):
+	}
+
+	result.Waited = time.Since(start)
+	return result
+}
+
+// NewFixedCooldown cria a pausa fixa de cooldown.duration, ou nil quando a
+// política configurada é outra ou a duração é zero. As políticas que consultam
+// o cluster ficam fora do pacote e são definidas em HeyExecutor.Cooldown.
+func NewFixedCooldown(params *parameters.BenchmarkParameters) CooldownPolicy {
+	if params.Cooldown.Policy != "" && params.Cooldown.Policy != parameters.CooldownFixed {
+		return nil
+	}
+
+	duration, _ := time.ParseDuration(params.Cooldown.Duration)
+	if duration <= 0 {
+		return nil
+	}
+	return FixedCooldown{Duration: duration}
+}
@@ -0,0 +1,444 @@
+package heyexec
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// Timeout padrão por requisição, igual ao do hey (em segundos)
+const defaultRequestTimeout = 20
+
+// Limite de conexões ociosas por host, igual ao do hey
+const maxIdleConns = 500
+
+// Chegadas planejadas que aguardam envio no modo open
+const openLoopBuffer = 1024
+
+// LoadEngine é o gerador de carga nativo em Go, alternativo ao binário hey.
+// Aceita os mesmos HeyParameters e mede cada requisição individualmente.
+type LoadEngine struct {
+	Parameters *parameters.BenchmarkParameters
+
+	client *http.Client
+	body   []byte
+}
+
+// NewLoadEngine cria uma nova instância do gerador de carga nativo
+func NewLoadEngine(params *parameters.BenchmarkParameters) (*LoadEngine, error) {
+	hey := params.Hey
+
+	idleConns := params.Concurrency
+	if params.Arrival.Mode == parameters.ArrivalOpen {
+		idleConns = params.Arrival.MaxInFlight
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         hey.Host,
+		},
+		MaxIdleConnsPerHost: min(idleConns, maxIdleConns),
+		DisableCompression:  hey.DisableCompression,
+		DisableKeepAlives:   hey.DisableKeepAlive,
+		Proxy:               http.ProxyFromEnvironment,
+	}
+
+	if hey.HTTP2 {
+		transport.ForceAttemptHTTP2 = true
+	} else {
+		// Um mapa vazio desabilita a negociação de HTTP/2, como o hey faz sem -h2
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	if hey.Proxy != "" {
+		proxyURL, err := url.Parse(hey.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("proxy inválido %s: %v", hey.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	timeout := hey.Timeout
+	if timeout == 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(timeout) * time.Second,
+	}
+
+	if hey.DisableRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	engine := &LoadEngine{
+		Parameters: params,
+		client:     client,
+	}
+
+	if hey.Body != "" {
+		engine.body = []byte(hey.Body)
+	} else if hey.BodyFile != "" {
+		body, err := os.ReadFile(hey.BodyFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler o arquivo de corpo %s: %v", hey.BodyFile, err)
+		}
+		engine.body = body
+	}
+
+	return engine, nil
+}
+
+// Run executa a carga configurada e retorna as medições de cada requisição.
+// Com LoadProfile a taxa segue o perfil; com Time a carga dura o tempo informado;
+// caso contrário envia Requests requisições.
+func (e *LoadEngine) Run(ctx context.Context) ([]RequestRecord, time.Duration, error) {
+	if e.Parameters.Hey.CPUs > 0 {
+		previous := runtime.GOMAXPROCS(e.Parameters.Hey.CPUs)
+		defer runtime.GOMAXPROCS(previous)
+	}
+
+	var duration time.Duration
+	if e.Parameters.Time != "" {
+		var err error
+		duration, err = time.ParseDuration(e.Parameters.Time)
+		if err != nil {
+			return nil, 0, fmt.Errorf("duração inválida %s: %v", e.Parameters.Time, err)
+		}
+	}
+
+	if e.Parameters.Arrival.Mode == parameters.ArrivalOpen {
+		schedule, limit := newConstantSchedule(e.Parameters.Arrival.Rate, duration), 0
+		if e.Parameters.LoadProfile != nil {
+			schedule = newRateSchedule(e.Parameters.LoadProfile)
+		} else if duration == 0 {
+			limit = e.Parameters.Requests
+		}
+		records, total := e.runOpenLoop(ctx, schedule, limit)
+		return records, total, nil
+	}
+
+	if e.Parameters.LoadProfile != nil {
+		records, total := e.runProfile(ctx, newRateSchedule(e.Parameters.LoadProfile))
+		return records, total, nil
+	}
+
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	concurrency := e.Parameters.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		records = make([]RequestRecord, 0, e.Parameters.Requests)
+		wg      sync.WaitGroup
+	)
+
+	start := time.Now()
+
+	for w := 0; w < concurrency; w++ {
+		// Distribui as requisições entre os workers sem perder o resto da divisão
+		quota := e.Parameters.Requests / concurrency
+		if w < e.Parameters.Requests%concurrency {
+			quota++
+		}
+
+		wg.Add(1)
+		go func(quota int) {
+			defer wg.Done()
+			local := e.runWorker(ctx, start, quota)
+
+			mu.Lock()
+			records = append(records, local...)
+			mu.Unlock()
+		}(quota)
+	}
+
+	wg.Wait()
+	total := time.Since(start)
+
+	return records, total, nil
+}
+
+// Probe envia uma única requisição isolada, usada pelos experimentos de cold start
+func (e *LoadEngine) Probe(ctx context.Context) RequestRecord {
+	return e.doRequest(ctx, time.Now())
+}
+
+// runProfile envia as requisições nos instantes definidos pelo perfil de carga.
+// Até Concurrency requisições ficam em andamento; se todos os workers estiverem
+// ocupados, as chegadas seguintes aguardam e são enviadas assim que possível.
+func (e *LoadEngine) runProfile(ctx context.Context, schedule *rateSchedule) ([]RequestRecord, time.Duration) {
+	concurrency := e.Parameters.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		mu       sync.Mutex
+		records  []RequestRecord
+		wg       sync.WaitGroup
+		arrivals = make(chan arrival, concurrency)
+	)
+
+	start := time.Now()
+	go schedule.dispatch(ctx, start, arrivals, newArrivalGap(e.Parameters.Arrival.Distribution), 0)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range arrivals {
+				record := e.doRequest(ctx, start)
+				record.Stage = a.stage
+
+				mu.Lock()
+				records = append(records, record)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return records, time.Since(start)
+}
+
+// runOpenLoop envia cada requisição no seu horário planejado, sem esperar pelas
+// respostas anteriores, evitando a omissão coordenada. A latência é medida a partir
+// do horário planejado; o tempo de serviço e o atraso de envio são registrados à parte.
+func (e *LoadEngine) runOpenLoop(ctx context.Context, schedule *rateSchedule, limit int) ([]RequestRecord, time.Duration) {
+	var (
+		mu       sync.Mutex
+		records  []RequestRecord
+		wg       sync.WaitGroup
+		arrivals = make(chan arrival, openLoopBuffer)
+		inFlight = make(chan struct{}, e.Parameters.Arrival.MaxInFlight)
+	)
+
+	start := time.Now()
+	go schedule.dispatch(ctx, start, arrivals, newArrivalGap(e.Parameters.Arrival.Distribution), limit)
+
+	for a := range arrivals {
+		// Acima do limite de requisições simultâneas o envio atrasa, e o atraso
+		// continua contabilizado porque a latência parte do horário planejado
+		inFlight <- struct{}{}
+
+		wg.Add(1)
+		go func(a arrival) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+
+			record := e.doRequest(ctx, start)
+			record.Stage = a.stage
+			record.Intended = a.at.Seconds()
+			record.SchedulingDelay = max(record.Offset-record.Intended, 0)
+			record.ServiceTime = record.ResponseTime
+			record.ResponseTime = record.ServiceTime + record.SchedulingDelay
+
+			mu.Lock()
+			records = append(records, record)
+			mu.Unlock()
+		}(a)
+	}
+
+	wg.Wait()
+	return records, time.Since(start)
+}
+
+// runWorker envia requisições em sequência até atingir a cota ou o contexto expirar.
+// Quando há limite de taxa, cada worker respeita RateLimit requisições por segundo.
+func (e *LoadEngine) runWorker(ctx context.Context, start time.Time, quota int) []RequestRecord {
+	var throttle <-chan time.Time
+	if e.Parameters.Hey.RateLimit > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(e.Parameters.Hey.RateLimit))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	timed := e.Parameters.Time != ""
+	var records []RequestRecord
+
+	for i := 0; timed || i < quota; i++ {
+		if throttle != nil {
+			select {
+			case <-ctx.Done():
+				return records
+			case <-throttle:
+			}
+		}
+
+		if ctx.Err() != nil {
+			return records
+		}
+
+		record := e.doRequest(ctx, start)
+
+		// Requisições interrompidas pelo fim da duração não entram no resultado
+		if timed && ctx.Err() != nil {
+			return records
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// requestPhases guarda os instantes e as durações medidos pelos callbacks do
+// httptrace, que podem rodar em goroutines do transporte depois de Do retornar
+type requestPhases struct {
+	mu sync.Mutex
+
+	dnsStart, connStart, reqStart, delayStart, respStart time.Time
+
+	dnsLookup, dnsDialup, requestWrite, responseDelay float64
+}
+
+// trace cria os callbacks que medem cada fase da requisição
+func (p *requestPhases) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.dnsLookup = time.Since(p.dnsStart).Seconds()
+		},
+		GetConn: func(string) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.connStart = time.Now()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if !info.Reused {
+				p.dnsDialup = time.Since(p.connStart).Seconds()
+			}
+			p.reqStart = time.Now()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.requestWrite = time.Since(p.reqStart).Seconds()
+			p.delayStart = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.responseDelay = time.Since(p.delayStart).Seconds()
+			p.respStart = time.Now()
+		},
+	}
+}
+
+// copyTo copia as durações medidas até agora para o registro e retorna o
+// instante do primeiro byte da resposta, zero se ele não chegou
+func (p *requestPhases) copyTo(record *RequestRecord) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	record.DNSLookup = p.dnsLookup
+	record.DNSDialup = p.dnsDialup
+	record.RequestWrite = p.requestWrite
+	record.ResponseDelay = p.responseDelay
+	return p.respStart
+}
+
+// doRequest envia uma única requisição e mede cada fase com httptrace, como o hey
+func (e *LoadEngine) doRequest(ctx context.Context, start time.Time) RequestRecord {
+	var phases requestPhases
+	var record RequestRecord
+
+	requestStart := time.Now()
+	record.Offset = requestStart.Sub(start).Seconds()
+
+	req, err := e.newRequest(httptrace.WithClientTrace(ctx, phases.trace()))
+	if err != nil {
+		record.Error = err.Error()
+		return record
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		record.ResponseTime = time.Since(requestStart).Seconds()
+		record.Error = err.Error()
+		phases.copyTo(&record)
+		return record
+	}
+
+	size, _ := io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	end := time.Now()
+	if respStart := phases.copyTo(&record); !respStart.IsZero() {
+		record.ResponseRead = end.Sub(respStart).Seconds()
+	}
+	record.ResponseTime = end.Sub(requestStart).Seconds()
+	record.StatusCode = resp.StatusCode
+	record.Size = size
+
+	return record
+}
+
+// newRequest monta a requisição HTTP a partir dos HeyParameters
+func (e *LoadEngine) newRequest(ctx context.Context) (*http.Request, error) {
+	hey := e.Parameters.Hey
+
+	method := strings.ToUpper(hey.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if e.body != nil {
+		body = bytes.NewReader(e.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, e.Parameters.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if hey.ContentType != "" {
+		req.Header.Set("Content-Type", hey.ContentType)
+	}
+
+	for key, value := range hey.Headers {
+		req.Header.Set(key, value)
+	}
+
+	if hey.Host != "" {
+		req.Host = hey.Host
+	}
+
+	if hey.Auth != "" {
+		user, password, _ := strings.Cut(hey.Auth, ":")
+		req.SetBasicAuth(user, password)
+	}
+
+	return req, nil
+}
@@ -2,10 +2,9 @@ package heyexec
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"os/exec"
-	"strings"
 	"time"
 
 	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
@@ -14,17 +13,39 @@ import (
 // Executar o teste de carga usando o hey
 type HeyExecutor struct {
 	Parameters *parameters.BenchmarkParameters
+
+	// Observer, quando definido, é avisado antes e depois de cada execução
+	Observer ExecutionObserver
+
+	// Cooldown é a pausa feita entre execuções; nil executa em sequência
+	Cooldown CooldownPolicy
+}
+
+// ExecutionObserver acompanha as execuções de ExecuteMultiple, por exemplo para
+// ler o estado do cluster logo antes e logo depois de cada uma. O índice começa em 1.
+type ExecutionObserver interface {
+	BeforeExecution(ctx context.Context, index int)
+	AfterExecution(ctx context.Context, index int, result *RunResult)
 }
 
 // Criar uma nova instância do executor do hey
 func NewHeyExecutor(parameters *parameters.BenchmarkParameters) *HeyExecutor {
 	return &HeyExecutor{
 		Parameters: parameters,
+		Cooldown:   NewFixedCooldown(parameters),
 	}
 }
 
-// Execute executa o comando hey com os parâmetros configurados
+// Execute executa uma rodada de carga com o gerador configurado (hey ou nativo)
 func (e *HeyExecutor) Execute() (*RunResult, error) {
+	if e.Parameters.Engine == parameters.EngineNative {
+		return e.executeNative()
+	}
+	return e.executeHey()
+}
+
+// executeHey executa o comando hey com os parâmetros configurados
+func (e *HeyExecutor) executeHey() (*RunResult, error) {
 	args := e.Parameters.ToHeyArgs()
 
 	startTime := time.Now()
@@ -54,35 +75,97 @@ func (e *HeyExecutor) Execute() (*RunResult, error) {
 		return result, fmt.Errorf("erro ao executar hey: %v\nSaída de erro: %s", runErr, rawStderr)
 	}
 
-	// Tentar fazer o unmarshalling da saída JSON do hey
-	var heyOutput HeyResult
+	// Com -o csv o hey imprime uma linha por requisição; caso contrário, o resumo textual
+	if e.Parameters.Hey.Output == "csv" {
+		records, err := ParseCSV(rawStdout)
+		if err != nil {
+			return result, fmt.Errorf("erro ao fazer parse da saída CSV do hey: %v\nSaída bruta: %s", err, rawStdout)
+		}
+
+		result.Records = records
+		result.HeyOutput = BuildHeyResult(e.Parameters.URL, records, recordsSpan(records), e.Parameters.Concurrency)
+		return result, nil
+	}
+
+	heyOutput, err := ParseSummary(rawStdout)
+	if err != nil {
+		return result, fmt.Errorf("erro ao fazer parse do resumo do hey: %v\nSaída bruta: %s", err, rawStdout)
+	}
+
+	heyOutput.URL = e.Parameters.URL
+	heyOutput.Concurrency = e.Parameters.Concurrency
+	result.HeyOutput = heyOutput
+	return result, nil
+}
 
-	// Solução de último recurso: Procurar o início do JSON (o primeiro '{')
-	// e descartar qualquer texto de cabeçalho (Summary:, etc.) que o hey insiste em imprimir.
-	jsonStart := strings.Index(rawStdout, "{")
-	if jsonStart == -1 {
-		return result, fmt.Errorf("erro ao fazer parse do JSON de saída do hey: não foi encontrado o início do objeto JSON ('{').\nSaída bruta: %s", rawStdout)
+// executeNative executa a carga com o gerador nativo em Go
+func (e *HeyExecutor) executeNative() (*RunResult, error) {
+	result := &RunResult{}
+
+	engine, err := NewLoadEngine(e.Parameters)
+	if err != nil {
+		result.Error = err
+		return result, fmt.Errorf("erro ao configurar o gerador de carga nativo: %v", err)
 	}
 
-	jsonBytes := []byte(rawStdout[jsonStart:])
-	err := json.Unmarshal(jsonBytes, &heyOutput)
+	result.StartTime = time.Now()
+	records, total, err := engine.Run(context.Background())
+	result.EndTime = time.Now()
 
 	if err != nil {
-		return result, fmt.Errorf("erro ao fazer parse do JSON de saída do hey: %v\nSaída bruta: %s", err, rawStdout)
+		result.Error = err
+		return result, fmt.Errorf("erro ao executar o gerador de carga nativo: %v", err)
 	}
 
-	result.HeyOutput = &heyOutput
+	result.Records = records
+	result.HeyOutput = BuildHeyResult(e.Parameters.URL, records, total, e.Parameters.Concurrency)
+
+	if e.Parameters.LoadProfile != nil {
+		result.Stages = buildStageResults(newRateSchedule(e.Parameters.LoadProfile), records)
+	}
+	result.OpenLoop = e.Parameters.Arrival.Mode == parameters.ArrivalOpen
+
 	return result, nil
 }
 
 func (e *HeyExecutor) ExecuteMultiple() ([]*RunResult, error) {
+	ctx := context.Background()
+
 	allResults := []*RunResult{}
 	for i := 0; i < e.Parameters.Execution; i++ {
+		// Pausa entre execuções, para que uma não herde o estado do cluster da anterior
+		var cooldown *CooldownResult
+		if i > 0 && e.Cooldown != nil {
+			fmt.Printf("  Pausa antes da próxima execução (%s)...\n", e.Parameters.Cooldown.Policy)
+			result := e.Cooldown.Wait(ctx)
+			cooldown = &result
+
+			switch {
+			case result.Error != "":
+				fmt.Printf("    Pausa interrompida após %s: %s\n", result.Waited.Round(time.Second), result.Error)
+			case !result.Reached:
+				fmt.Printf("    Timeout da pausa após %s; a execução começa sem o estado esperado\n", result.Waited.Round(time.Second))
+			default:
+				fmt.Printf("    Pausa de %s\n", result.Waited.Round(time.Second))
+			}
+		}
+
 		fmt.Printf("  Execução %d/%d em andamento...\n", i+1, e.Parameters.Execution)
 
+		if e.Observer != nil {
+			e.Observer.BeforeExecution(ctx, i+1)
+		}
+
 		runResult, err := e.Execute()
+		if runResult != nil {
+			runResult.Cooldown = cooldown
+		}
 		allResults = append(allResults, runResult)
 
+		if e.Observer != nil {
+			e.Observer.AfterExecution(ctx, i+1, runResult)
+		}
+
 		if err != nil {
 			fmt.Printf("    Erro na execução %d: %v\n", i+1, err)
 			// Decide se quer parar ou continuar em caso de erro
@@ -4,32 +4,25 @@ import "time"
 
 // HeyResult representa a estrutura da saída JSON do hey
 type HeyResult struct {
-	URL                 string         `json:"url"`
-	Requests            int            `json:"requests"`
-	Duration            float64        `json:"duration"` // em segundos
-	Wait                float64        `json:"wait"`     // em segundos
-	Total               float64        `json:"total"`    // em segundos
-	BytesTotal          int            `json:"bytes_total"`
-	BytesPerSecond      float64        `json:"bytes_per_second"`
-	RequestsPerSecond   float64        `json:"requests_per_second"`
-	StatusCodeDist      map[string]int `json:"status_code_dist"`
-	ErrorDist           map[string]int `json:"error_dist"`
-	LatencyDistribution []struct {
-		Percentage float64 `json:"percentage"`
-		Latency    float64 `json:"latency"` // em segundos
-	} `json:"latency_distribution"`
-	Histogram []struct {
-		Mark    float64 `json:"mark"`
-		Count   int     `json:"count"`
-		Percent float64 `json:"percent"`
-	} `json:"histogram"`
-	Fastest           float64 `json:"fastest"`
-	Slowest           float64 `json:"slowest"`
-	Average           float64 `json:"average"`
-	RequestsLatency   float64 `json:"requests_latency"`
-	TotalDataTransfer int     `json:"total_data_transfer"`
-	TotalRequests     int     `json:"total_requests"`
-	Concurrency       int     `json:"concurrency"`
+	URL                 string              `json:"url"`
+	Requests            int                 `json:"requests"`
+	Duration            float64             `json:"duration"` // em segundos
+	Wait                float64             `json:"wait"`     // em segundos
+	Total               float64             `json:"total"`    // em segundos
+	BytesTotal          int                 `json:"bytes_total"`
+	BytesPerSecond      float64             `json:"bytes_per_second"`
+	RequestsPerSecond   float64             `json:"requests_per_second"`
+	StatusCodeDist      map[string]int      `json:"status_code_dist"`
+	ErrorDist           map[string]int      `json:"error_dist"`
+	LatencyDistribution []LatencyPercentile `json:"latency_distribution"`
+	Histogram           []HistogramBucket   `json:"histogram"`
+	Fastest             float64             `json:"fastest"`
+	Slowest             float64             `json:"slowest"`
+	Average             float64             `json:"average"`
+	RequestsLatency     float64             `json:"requests_latency"`
+	TotalDataTransfer   int                 `json:"total_data_transfer"`
+	TotalRequests       int                 `json:"total_requests"`
+	Concurrency         int                 `json:"concurrency"`
 	// Adicione outros campos conforme necessário com suas tags json
 
 	// Campos adicionados com base na saída detalhada do hey
@@ -43,16 +36,9 @@ type HeyResult struct {
 		SizePerRequest int     `json:"size_per_request"`
 	} `json:"summary"`
 	Latency struct {
-		Distribution []struct {
-			Percentage float64 `json:"percentage"`
-			Latency    float64 `json:"latency"`
-		} `json:"distribution"`
-		Histogram []struct {
-			Mark    float64 `json:"mark"`
-			Count   int     `json:"count"`
-			Percent float64 `json:"percent"`
-		} `json:"histogram"`
-		Details struct {
+		Distribution []LatencyPercentile `json:"distribution"`
+		Histogram    []HistogramBucket   `json:"histogram"`
+		Details      struct {
 			DNSDialup float64 `json:"dns_dialup"`
 			DNSLookup float64 `json:"dns_lookup"`
 			ReqWrite  float64 `json:"req_write"`
@@ -63,12 +49,40 @@ type HeyResult struct {
 	StatusCodeCount map[string]int `json:"status_code_count"`
 }
 
+// LatencyPercentile representa um ponto da distribuição de latência
+type LatencyPercentile struct {
+	Percentage float64 `json:"percentage"` // fração entre 0 e 1
+	Latency    float64 `json:"latency"`    // em segundos
+}
+
+// HistogramBucket representa uma faixa do histograma de tempos de resposta
+type HistogramBucket struct {
+	Mark    float64 `json:"mark"` // limite superior da faixa, em segundos
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"` // fração entre 0 e 1
+}
+
 // RunResult armazena os resultados de uma única execução do hey
 type RunResult struct {
 	HeyOutput *HeyResult
+	Records   []RequestRecord // Medições individuais, quando disponíveis
+	Stages    []StageResult   // Resumo por estágio, quando há perfil de carga
+	OpenLoop  bool            // Requisições enviadas em horários planejados (modo open)
 	HeyStdout string
 	HeyStderr string
 	StartTime time.Time
 	EndTime   time.Time
 	Error     error
+
+	// Pausa feita antes da execução, nil na primeira ou sem política configurada
+	Cooldown *CooldownResult
+}
+
+// LatencyStats calcula as estatísticas de latência da execução a partir das
+// medições individuais. Retorna false quando a execução não possui medições.
+func (r *RunResult) LatencyStats(percentiles []float64) (LatencyStats, bool) {
+	if len(r.Records) == 0 {
+		return LatencyStats{}, false
+	}
+	return ComputeLatencyStats(r.Records, percentiles), true
 }
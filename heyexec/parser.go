@@ -0,0 +1,374 @@
+package heyexec
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Seções do resumo textual impresso pelo hey
+const (
+	sectionNone = iota
+	sectionSummary
+	sectionHistogram
+	sectionDistribution
+	sectionDetails
+	sectionStatusCodes
+	sectionErrors
+)
+
+// Cabeçalho da saída CSV do hey (-o csv)
+var csvHeader = []string{
+	"response-time", "DNS+dialup", "DNS", "Request-write",
+	"Response-delay", "Response-read", "status-code", "offset",
+}
+
+// ParseSummary interpreta o resumo textual que o hey imprime ao final de uma execução
+func ParseSummary(output string) (*HeyResult, error) {
+	result := &HeyResult{
+		StatusCodeDist: make(map[string]int),
+		ErrorDist:      make(map[string]int),
+	}
+
+	section := sectionNone
+	foundSummary := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case line == "Summary:":
+			section = sectionSummary
+			foundSummary = true
+			continue
+		case line == "Response time histogram:":
+			section = sectionHistogram
+			continue
+		case line == "Latency distribution:":
+			section = sectionDistribution
+			continue
+		case strings.HasPrefix(line, "Details"):
+			section = sectionDetails
+			continue
+		case line == "Status code distribution:":
+			section = sectionStatusCodes
+			continue
+		case line == "Error distribution:":
+			section = sectionErrors
+			continue
+		}
+
+		var err error
+		switch section {
+		case sectionSummary:
+			err = parseSummaryLine(result, line)
+		case sectionHistogram:
+			err = parseHistogramLine(result, line)
+		case sectionDistribution:
+			err = parseDistributionLine(result, line)
+		case sectionDetails:
+			err = parseDetailsLine(result, line)
+		case sectionStatusCodes:
+			err = parseStatusCodeLine(result, line)
+		case sectionErrors:
+			err = parseErrorLine(result, line)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("erro ao interpretar a linha %q do resumo do hey: %v", raw, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler o resumo do hey: %v", err)
+	}
+
+	if !foundSummary {
+		return nil, fmt.Errorf("resumo do hey não encontrado na saída (seção 'Summary:' ausente)")
+	}
+
+	// O hey não imprime o total de requisições; ele é a soma das respostas e dos erros
+	for _, count := range result.StatusCodeDist {
+		result.Requests += count
+	}
+	for _, count := range result.ErrorDist {
+		result.Requests += count
+	}
+
+	result.TotalRequests = result.Requests
+	result.Duration = result.Summary.Total
+	result.Total = result.Summary.Total
+	result.Fastest = result.Summary.Fastest
+	result.Slowest = result.Summary.Slowest
+	result.Average = result.Summary.Average
+	result.RequestsLatency = result.Summary.Average
+	result.RequestsPerSecond = result.Summary.RequestsPerSec
+	result.BytesTotal = result.Summary.TotalData
+	result.TotalDataTransfer = result.Summary.TotalData
+	if result.Summary.Total > 0 {
+		result.BytesPerSecond = float64(result.Summary.TotalData) / result.Summary.Total
+	}
+
+	histogramTotal := 0
+	for _, bucket := range result.Latency.Histogram {
+		histogramTotal += bucket.Count
+	}
+	for i := range result.Latency.Histogram {
+		if histogramTotal > 0 {
+			result.Latency.Histogram[i].Percent = float64(result.Latency.Histogram[i].Count) / float64(histogramTotal)
+		}
+	}
+
+	result.Histogram = result.Latency.Histogram
+	result.LatencyDistribution = result.Latency.Distribution
+	result.StatusCodeCount = result.StatusCodeDist
+
+	return result, nil
+}
+
+// parseSummaryLine interpreta linhas como "Total:	0.0523 secs" e "Requests/sec:	3823.6073"
+func parseSummaryLine(result *HeyResult, line string) error {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("separador ':' ausente")
+	}
+	value = strings.TrimSpace(value)
+
+	switch strings.TrimSpace(key) {
+	case "Total":
+		return parseSeconds(value, &result.Summary.Total)
+	case "Slowest":
+		return parseSeconds(value, &result.Summary.Slowest)
+	case "Fastest":
+		return parseSeconds(value, &result.Summary.Fastest)
+	case "Average":
+		return parseSeconds(value, &result.Summary.Average)
+	case "Requests/sec":
+		return parseFloat(value, &result.Summary.RequestsPerSec)
+	case "Total data":
+		return parseBytes(value, &result.Summary.TotalData)
+	case "Size/request":
+		return parseBytes(value, &result.Summary.SizePerRequest)
+	}
+
+	return nil
+}
+
+// parseHistogramLine interpreta linhas como "0.004 [120]	|■■■■"
+func parseHistogramLine(result *HeyResult, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return fmt.Errorf("faixa do histograma incompleta")
+	}
+
+	var bucket HistogramBucket
+	if err := parseFloat(fields[0], &bucket.Mark); err != nil {
+		return err
+	}
+
+	count, err := strconv.Atoi(strings.Trim(fields[1], "[]"))
+	if err != nil {
+		return err
+	}
+	bucket.Count = count
+
+	result.Latency.Histogram = append(result.Latency.Histogram, bucket)
+	return nil
+}
+
+// parseDistributionLine interpreta linhas como "99% in 0.0294 secs"
+func parseDistributionLine(result *HeyResult, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[1] != "in" {
+		return fmt.Errorf("formato esperado '<p>%% in <latência> secs'")
+	}
+
+	var point LatencyPercentile
+	if err := parseFloat(strings.TrimSuffix(fields[0], "%"), &point.Percentage); err != nil {
+		return err
+	}
+	point.Percentage /= 100
+
+	if err := parseFloat(fields[2], &point.Latency); err != nil {
+		return err
+	}
+
+	result.Latency.Distribution = append(result.Latency.Distribution, point)
+	return nil
+}
+
+// parseDetailsLine interpreta linhas como "DNS+dialup:	0.0033 secs, 0.0010 secs, 0.0303 secs".
+// Apenas a média (primeiro valor) é armazenada no HeyResult.
+func parseDetailsLine(result *HeyResult, line string) error {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("separador ':' ausente")
+	}
+
+	average, _, _ := strings.Cut(value, ",")
+	average = strings.TrimSpace(average)
+
+	switch strings.TrimSpace(key) {
+	case "DNS+dialup":
+		return parseSeconds(average, &result.Latency.Details.DNSDialup)
+	case "DNS-lookup":
+		return parseSeconds(average, &result.Latency.Details.DNSLookup)
+	case "req write":
+		return parseSeconds(average, &result.Latency.Details.ReqWrite)
+	case "resp wait":
+		return parseSeconds(average, &result.Latency.Details.RespWait)
+	case "resp read":
+		return parseSeconds(average, &result.Latency.Details.RespRead)
+	}
+
+	return nil
+}
+
+// parseStatusCodeLine interpreta linhas como "[200]	200 responses"
+func parseStatusCodeLine(result *HeyResult, line string) error {
+	code, rest, err := parseBracketPrefix(line)
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return fmt.Errorf("contagem de respostas ausente")
+	}
+
+	count, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return err
+	}
+
+	result.StatusCodeDist[code] += count
+	return nil
+}
+
+// parseErrorLine interpreta linhas como "[10]	Get http://...: dial tcp: connection refused"
+func parseErrorLine(result *HeyResult, line string) error {
+	countStr, message, err := parseBracketPrefix(line)
+	if err != nil {
+		return err
+	}
+
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		return err
+	}
+
+	result.ErrorDist[strings.TrimSpace(message)] += count
+	return nil
+}
+
+// parseBracketPrefix separa "[valor] resto" em valor e resto
+func parseBracketPrefix(line string) (string, string, error) {
+	if !strings.HasPrefix(line, "[") {
+		return "", "", fmt.Errorf("formato esperado '[valor] ...'")
+	}
+
+	end := strings.Index(line, "]")
+	if end == -1 {
+		return "", "", fmt.Errorf("']' ausente")
+	}
+
+	return line[1:end], line[end+1:], nil
+}
+
+// parseSeconds interpreta valores como "0.0523 secs"
+func parseSeconds(value string, target *float64) error {
+	return parseFloat(strings.TrimSpace(strings.TrimSuffix(value, "secs")), target)
+}
+
+// parseBytes interpreta valores como "2200 bytes"
+func parseBytes(value string, target *int) error {
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(value, "bytes")))
+	if err != nil {
+		return err
+	}
+	*target = n
+	return nil
+}
+
+func parseFloat(value string, target *float64) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*target = f
+	return nil
+}
+
+// ParseCSV interpreta a saída do hey com -o csv, que contém uma linha por requisição
+func ParseCSV(output string) ([]RequestRecord, error) {
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o cabeçalho CSV do hey: %v", err)
+	}
+
+	for i, name := range csvHeader {
+		if header[i] != name {
+			return nil, fmt.Errorf("cabeçalho CSV inesperado: coluna %d é %q, esperado %q", i+1, header[i], name)
+		}
+	}
+
+	var records []RequestRecord
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler a linha %d do CSV do hey: %v", line, err)
+		}
+
+		record, err := parseCSVRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao interpretar a linha %d do CSV do hey: %v", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseCSVRow converte uma linha do CSV do hey em RequestRecord
+func parseCSVRow(row []string) (RequestRecord, error) {
+	var record RequestRecord
+
+	floats := []*float64{
+		&record.ResponseTime, &record.DNSDialup, &record.DNSLookup, &record.RequestWrite,
+		&record.ResponseDelay, &record.ResponseRead,
+	}
+	for i, target := range floats {
+		if err := parseFloat(row[i], target); err != nil {
+			return record, fmt.Errorf("coluna %s: %v", csvHeader[i], err)
+		}
+	}
+
+	status, err := strconv.Atoi(row[6])
+	if err != nil {
+		return record, fmt.Errorf("coluna %s: %v", csvHeader[6], err)
+	}
+	record.StatusCode = status
+
+	if err := parseFloat(row[7], &record.Offset); err != nil {
+		return record, fmt.Errorf("coluna %s: %v", csvHeader[7], err)
+	}
+
+	return record, nil
+}
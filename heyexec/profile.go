@@ -0,0 +1,289 @@
+package heyexec
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// Intervalo usado para reavaliar a taxa alvo quando ela é zero
+const idleRateProbe = 10 * time.Millisecond
+
+// Maior passo de integração da taxa alvo ao calcular a próxima chegada
+const maxIntegrationStep = time.Millisecond
+
+// Número de amostras usadas para calcular a taxa alvo média de um estágio
+const targetRateSamples = 1000
+
+// StageResult resume as requisições de um estágio do perfil de carga em uma execução
+type StageResult struct {
+	Index       int
+	Name        string
+	Type        string
+	Start       float64 // Início do estágio relativo ao início da execução, em segundos
+	End         float64
+	TargetRPS   float64 // Taxa alvo média do estágio
+	AchievedRPS float64 // Requisições iniciadas no estágio por segundo
+	Requests    int
+	Errors      int // Erros de transporte e respostas 4xx/5xx
+	AvgLatency  float64
+	P99Latency  float64
+}
+
+// scheduledStage posiciona um estágio na linha do tempo da execução
+type scheduledStage struct {
+	index int
+	start time.Duration
+	end   time.Duration
+	stage parameters.LoadStage
+
+	// Valores já interpretados das durações do estágio
+	spikeStart    time.Duration
+	spikeDuration time.Duration
+	period        time.Duration
+}
+
+// rateSchedule calcula a taxa alvo de requisições em cada instante da execução
+type rateSchedule struct {
+	stages []scheduledStage
+	total  time.Duration
+}
+
+// newRateSchedule posiciona os estágios do perfil em sequência. Os valores já foram
+// validados por parameters.ValidateParameters.
+func newRateSchedule(profile *parameters.LoadProfile) *rateSchedule {
+	schedule := &rateSchedule{}
+
+	var offset time.Duration
+	for i, stage := range profile.Stages {
+		duration, _ := time.ParseDuration(stage.Duration)
+		s := scheduledStage{
+			index: i,
+			start: offset,
+			end:   offset + duration,
+			stage: stage,
+		}
+		s.spikeStart, _ = time.ParseDuration(stage.SpikeStart)
+		s.spikeDuration, _ = time.ParseDuration(stage.SpikeDuration)
+		s.period, _ = time.ParseDuration(stage.Period)
+
+		schedule.stages = append(schedule.stages, s)
+		offset += duration
+	}
+	schedule.total = offset
+
+	return schedule
+}
+
+// newConstantSchedule cria uma linha do tempo com um único estágio de taxa fixa.
+// Com duração zero a linha do tempo não tem fim e o número de chegadas é limitado por quem a consome.
+func newConstantSchedule(rate float64, duration time.Duration) *rateSchedule {
+	if duration <= 0 {
+		duration = time.Duration(math.MaxInt64)
+	}
+
+	stage := parameters.LoadStage{Type: parameters.StageConstant, RPS: rate}
+	return &rateSchedule{
+		stages: []scheduledStage{{index: 0, start: 0, end: duration, stage: stage}},
+		total:  duration,
+	}
+}
+
+// stageAt retorna o estágio ativo no instante informado
+func (s *rateSchedule) stageAt(elapsed time.Duration) (*scheduledStage, bool) {
+	i := sort.Search(len(s.stages), func(i int) bool {
+		return s.stages[i].end > elapsed
+	})
+	if i == len(s.stages) {
+		return nil, false
+	}
+	return &s.stages[i], true
+}
+
+// rateAt retorna a taxa alvo (req/s) e o índice do estágio no instante informado
+func (s *rateSchedule) rateAt(elapsed time.Duration) (float64, int) {
+	stage, ok := s.stageAt(elapsed)
+	if !ok {
+		return 0, -1
+	}
+	return stage.rateAt(elapsed - stage.start), stage.index
+}
+
+// rateAt calcula a taxa alvo do estágio, sendo t relativo ao início do estágio
+func (s *scheduledStage) rateAt(t time.Duration) float64 {
+	stage := s.stage
+	progress := t.Seconds() / (s.end - s.start).Seconds()
+
+	switch stage.Type {
+	case parameters.StageRamp:
+		return stage.FromRPS + (stage.ToRPS-stage.FromRPS)*progress
+	case parameters.StageStep:
+		// Cada degrau dura 1/steps do estágio; o primeiro usa from_rps e o último to_rps
+		step := math.Min(math.Floor(progress*float64(stage.Steps)), float64(stage.Steps-1))
+		if stage.Steps == 1 {
+			return stage.ToRPS
+		}
+		return stage.FromRPS + (stage.ToRPS-stage.FromRPS)*step/float64(stage.Steps-1)
+	case parameters.StageSpike:
+		if t >= s.spikeStart && t < s.spikeStart+s.spikeDuration {
+			return stage.SpikeRPS
+		}
+		return stage.RPS
+	case parameters.StageSine:
+		rate := stage.RPS + stage.Amplitude*math.Sin(2*math.Pi*t.Seconds()/s.period.Seconds())
+		return math.Max(rate, 0)
+	default:
+		return stage.RPS
+	}
+}
+
+// meanRate calcula a taxa alvo média do estágio por amostragem
+func (s *scheduledStage) meanRate() float64 {
+	duration := s.end - s.start
+	sum := 0.0
+	for i := 0; i < targetRateSamples; i++ {
+		sum += s.rateAt(duration * time.Duration(i) / targetRateSamples)
+	}
+	return sum / targetRateSamples
+}
+
+// arrival representa uma requisição agendada pelo perfil de carga
+type arrival struct {
+	at    time.Duration // instante planejado, relativo ao início da execução
+	stage int
+}
+
+// nextArrival integra a taxa alvo a partir de elapsed até acumular "need"
+// chegadas esperadas e retorna esse instante. Com need = 1 as chegadas ficam
+// igualmente espaçadas mesmo quando a taxa varia. Retorna false ao fim do perfil.
+func (s *rateSchedule) nextArrival(elapsed time.Duration, need float64) (arrival, bool) {
+	t := elapsed
+	for t < s.total {
+		rate, _ := s.rateAt(t)
+		if rate <= 0 {
+			t += idleRateProbe
+			continue
+		}
+
+		// Passos curtos acompanham a variação da taxa dentro do intervalo
+		step := time.Duration(need / rate * float64(time.Second))
+		if step <= maxIntegrationStep {
+			t += step
+			break
+		}
+		t += maxIntegrationStep
+		need -= rate * maxIntegrationStep.Seconds()
+	}
+
+	if t >= s.total {
+		return arrival{}, false
+	}
+
+	_, stage := s.rateAt(t)
+	return arrival{at: t, stage: stage}, true
+}
+
+// newArrivalGap retorna a função que sorteia o "need" de cada chegada: 1 para
+// intervalos constantes ou uma amostra exponencial de média 1 para Poisson
+func newArrivalGap(distribution string) func() float64 {
+	if distribution != parameters.DistributionPoisson {
+		return func() float64 { return 1 }
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return rng.ExpFloat64
+}
+
+// dispatch envia as chegadas no canal, cada uma no seu instante planejado, e fecha
+// o canal ao fim da linha do tempo, após limit chegadas (quando maior que zero) ou
+// quando o contexto é cancelado
+func (s *rateSchedule) dispatch(ctx context.Context, start time.Time, out chan<- arrival, gap func() float64, limit int) {
+	defer close(out)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var elapsed time.Duration
+	for sent := 0; limit <= 0 || sent < limit; sent++ {
+		next, ok := s.nextArrival(elapsed, gap())
+		if !ok {
+			return
+		}
+
+		if wait := time.Until(start.Add(next.at)); wait > 0 {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case out <- next:
+		}
+
+		elapsed = next.at
+	}
+}
+
+// buildStageResults resume as requisições de cada estágio do perfil
+func buildStageResults(schedule *rateSchedule, records []RequestRecord) []StageResult {
+	results := make([]StageResult, len(schedule.stages))
+	latencies := make([][]float64, len(schedule.stages))
+
+	for i, s := range schedule.stages {
+		results[i] = StageResult{
+			Index:     i,
+			Name:      s.stage.Name,
+			Type:      s.stage.Type,
+			Start:     s.start.Seconds(),
+			End:       s.end.Seconds(),
+			TargetRPS: s.meanRate(),
+		}
+	}
+
+	for _, r := range records {
+		if r.Stage < 0 || r.Stage >= len(results) {
+			continue
+		}
+		results[r.Stage].Requests++
+		if r.Error != "" || r.StatusCode >= 400 {
+			results[r.Stage].Errors++
+		}
+		if r.Error == "" {
+			latencies[r.Stage] = append(latencies[r.Stage], r.ResponseTime)
+		}
+	}
+
+	for i := range results {
+		if duration := results[i].End - results[i].Start; duration > 0 {
+			results[i].AchievedRPS = float64(results[i].Requests) / duration
+		}
+
+		if len(latencies[i]) == 0 {
+			continue
+		}
+		sort.Float64s(latencies[i])
+		sum := 0.0
+		for _, l := range latencies[i] {
+			sum += l
+		}
+		results[i].AvgLatency = sum / float64(len(latencies[i]))
+		results[i].P99Latency = Percentile(latencies[i], 99)
+	}
+
+	return results
+}
@@ -0,0 +1,233 @@
+package heyexec
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RequestRecord armazena as medições de uma única requisição.
+// Todos os tempos estão em segundos, como na saída do hey.
+type RequestRecord struct {
+	Offset        float64 // Início da requisição relativo ao início da execução
+	ResponseTime  float64 // Tempo total da requisição; no modo open, medido a partir do horário planejado
+	DNSDialup     float64 // DNS + estabelecimento de conexão
+	DNSLookup     float64
+	RequestWrite  float64
+	ResponseDelay float64 // Espera até o primeiro byte da resposta
+	ResponseRead  float64
+	StatusCode    int
+	Size          int64  // Bytes do corpo da resposta
+	Error         string // Erro de transporte, vazio em caso de resposta
+	Stage         int    // Índice do estágio do perfil de carga (apenas com load_profile)
+
+	// Apenas no modo open: horário planejado de envio, atraso até o envio efetivo
+	// e tempo de serviço (do envio efetivo até a resposta)
+	Intended        float64
+	SchedulingDelay float64
+	ServiceTime     float64
+}
+
+// Percentis reportados na distribuição de latência, iguais aos do hey
+var defaultDistributionPercentiles = []float64{10, 25, 50, 75, 90, 95, 99}
+
+// Número de faixas do histograma de tempos de resposta, igual ao do hey
+const histogramBuckets = 10
+
+// BuildHeyResult monta um HeyResult a partir das medições individuais,
+// reproduzindo o resumo que o hey calcula ao final de uma execução
+func BuildHeyResult(url string, records []RequestRecord, total time.Duration, concurrency int) *HeyResult {
+	result := &HeyResult{
+		URL:            url,
+		Requests:       len(records),
+		TotalRequests:  len(records),
+		Concurrency:    concurrency,
+		StatusCodeDist: make(map[string]int),
+		ErrorDist:      make(map[string]int),
+	}
+
+	totalSeconds := total.Seconds()
+	result.Duration = totalSeconds
+	result.Total = totalSeconds
+	result.Summary.Total = totalSeconds
+
+	var latencies []float64
+	var dnsDialup, dnsLookup, reqWrite, respWait, respRead float64
+	var bytesTotal int64
+
+	for _, r := range records {
+		if r.Error != "" {
+			result.ErrorDist[r.Error]++
+			continue
+		}
+
+		latencies = append(latencies, r.ResponseTime)
+		result.StatusCodeDist[fmt.Sprintf("%d", r.StatusCode)]++
+		bytesTotal += r.Size
+
+		dnsDialup += r.DNSDialup
+		dnsLookup += r.DNSLookup
+		reqWrite += r.RequestWrite
+		respWait += r.ResponseDelay
+		respRead += r.ResponseRead
+	}
+
+	result.BytesTotal = int(bytesTotal)
+	result.TotalDataTransfer = int(bytesTotal)
+	result.Summary.TotalData = int(bytesTotal)
+
+	if totalSeconds > 0 {
+		result.RequestsPerSecond = float64(len(records)) / totalSeconds
+		result.BytesPerSecond = float64(bytesTotal) / totalSeconds
+	}
+	result.Summary.RequestsPerSec = result.RequestsPerSecond
+
+	if len(latencies) == 0 {
+		return result
+	}
+
+	sort.Float64s(latencies)
+
+	count := float64(len(latencies))
+	sum := 0.0
+	for _, l := range latencies {
+		sum += l
+	}
+
+	result.Fastest = latencies[0]
+	result.Slowest = latencies[len(latencies)-1]
+	result.Average = sum / count
+	result.RequestsLatency = result.Average
+	result.Summary.Fastest = result.Fastest
+	result.Summary.Slowest = result.Slowest
+	result.Summary.Average = result.Average
+	result.Summary.SizePerRequest = int(bytesTotal / int64(len(latencies)))
+
+	result.Latency.Details.DNSDialup = dnsDialup / count
+	result.Latency.Details.DNSLookup = dnsLookup / count
+	result.Latency.Details.ReqWrite = reqWrite / count
+	result.Latency.Details.RespWait = respWait / count
+	result.Latency.Details.RespRead = respRead / count
+
+	for _, p := range defaultDistributionPercentiles {
+		result.LatencyDistribution = append(result.LatencyDistribution, LatencyPercentile{
+			Percentage: p / 100,
+			Latency:    Percentile(latencies, p),
+		})
+	}
+	result.Latency.Distribution = result.LatencyDistribution
+
+	result.Histogram = buildHistogram(latencies)
+	result.Latency.Histogram = result.Histogram
+	result.StatusCodeCount = result.StatusCodeDist
+
+	return result
+}
+
+// recordsSpan retorna o intervalo entre o início da primeira requisição e o fim da última
+func recordsSpan(records []RequestRecord) time.Duration {
+	span := 0.0
+	for _, r := range records {
+		if end := r.Offset + r.ResponseTime; end > span {
+			span = end
+		}
+	}
+	return time.Duration(span * float64(time.Second))
+}
+
+// LatencyStats resume as latências das requisições bem-sucedidas de uma execução
+type LatencyStats struct {
+	Count       int
+	Mean        float64
+	StdDev      float64 // Desvio padrão populacional
+	Min         float64
+	Max         float64
+	Percentiles []LatencyPercentile
+}
+
+// ComputeLatencyStats calcula média, desvio padrão, extremos e os percentis
+// informados (0-100) a partir das medições individuais
+func ComputeLatencyStats(records []RequestRecord, percentiles []float64) LatencyStats {
+	var latencies []float64
+	for _, r := range records {
+		if r.Error == "" {
+			latencies = append(latencies, r.ResponseTime)
+		}
+	}
+
+	stats := LatencyStats{Count: len(latencies)}
+	if len(latencies) == 0 {
+		return stats
+	}
+
+	sort.Float64s(latencies)
+
+	sum := 0.0
+	for _, l := range latencies {
+		sum += l
+	}
+	stats.Mean = sum / float64(len(latencies))
+
+	sqDiff := 0.0
+	for _, l := range latencies {
+		sqDiff += (l - stats.Mean) * (l - stats.Mean)
+	}
+	stats.StdDev = math.Sqrt(sqDiff / float64(len(latencies)))
+
+	stats.Min = latencies[0]
+	stats.Max = latencies[len(latencies)-1]
+
+	for _, p := range percentiles {
+		stats.Percentiles = append(stats.Percentiles, LatencyPercentile{
+			Percentage: p / 100,
+			Latency:    Percentile(latencies, p),
+		})
+	}
+
+	return stats
+}
+
+// Percentile retorna o percentil p (0-100) de uma lista ordenada pelo método do
+// posto mais próximo, ou seja, sempre um valor efetivamente observado
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// buildHistogram distribui as latências ordenadas em faixas de largura igual entre a mais rápida e a mais lenta
+func buildHistogram(sorted []float64) []HistogramBucket {
+	fastest := sorted[0]
+	slowest := sorted[len(sorted)-1]
+	width := (slowest - fastest) / histogramBuckets
+
+	buckets := make([]HistogramBucket, histogramBuckets+1)
+	for i := range buckets {
+		buckets[i].Mark = fastest + width*float64(i)
+	}
+	buckets[histogramBuckets].Mark = slowest
+
+	bi := 0
+	for _, l := range sorted {
+		for bi < histogramBuckets && l > buckets[bi].Mark {
+			bi++
+		}
+		buckets[bi].Count++
+	}
+
+	for i := range buckets {
+		buckets[i].Percent = float64(buckets[i].Count) / float64(len(sorted))
+	}
+
+	return buckets
+}
@@ -0,0 +1,226 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrNotFound indica que o recurso ou a API não existe no cluster, como o
+// metrics.k8s.io sem o Metrics Server instalado
+var ErrNotFound = errors.New("not found")
+
+// Client acessa a API REST do Kubernetes sem depender do client-go
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+}
+
+// NewClient cria uma nova instância do Client
+func NewClient(config *Config) *Client {
+	return &Client{
+		config:     config,
+		httpClient: config.httpClient(),
+	}
+}
+
+// Pod contém os campos do objeto Pod usados pelo coletor
+type Pod struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     PodSpec    `json:"spec"`
+	Status   PodStatus  `json:"status"`
+}
+
+// ObjectMeta contém os metadados comuns dos objetos
+type ObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Labels            map[string]string `json:"labels"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+	DeletionTimestamp *time.Time        `json:"deletionTimestamp"`
+}
+
+// PodSpec contém os containers declarados no pod
+type PodSpec struct {
+	NodeName   string      `json:"nodeName"`
+	Containers []Container `json:"containers"`
+}
+
+// Container contém os recursos solicitados e os limites de um container
+type Container struct {
+	Name      string `json:"name"`
+	Resources struct {
+		Requests map[string]string `json:"requests"`
+		Limits   map[string]string `json:"limits"`
+	} `json:"resources"`
+}
+
+// PodStatus contém a fase, as condições e o estado dos containers
+type PodStatus struct {
+	Phase             string            `json:"phase"`
+	StartTime         *time.Time        `json:"startTime"`
+	Conditions        []PodCondition    `json:"conditions"`
+	ContainerStatuses []ContainerStatus `json:"containerStatuses"`
+}
+
+// PodCondition é uma condição do pod, como PodScheduled ou Ready
+type PodCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// ContainerStatus é o estado atual de um container do pod
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int    `json:"restartCount"`
+	State        struct {
+		Running *struct {
+			StartedAt time.Time `json:"startedAt"`
+		} `json:"running"`
+		Waiting *struct {
+			Reason string `json:"reason"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason     string    `json:"reason"`
+			StartedAt  time.Time `json:"startedAt"`
+			FinishedAt time.Time `json:"finishedAt"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
+// PodMetrics é o uso de recursos de um pod segundo o metrics.k8s.io
+type PodMetrics struct {
+	Metadata   ObjectMeta `json:"metadata"`
+	Timestamp  time.Time  `json:"timestamp"`
+	Containers []struct {
+		Name  string            `json:"name"`
+		Usage map[string]string `json:"usage"`
+	} `json:"containers"`
+}
+
+// ListPods lista os pods do namespace (todos, se vazio) que atendem ao seletor de labels
+func (c *Client) ListPods(ctx context.Context, namespace, labelSelector string) ([]Pod, error) {
+	var list struct {
+		Items []Pod `json:"items"`
+	}
+	if err := c.get(ctx, namespacedPath("/api/v1", namespace, "pods"), selectorQuery("labelSelector", labelSelector), &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ListPodMetrics lista o uso de CPU e memória dos pods do namespace (todos, se vazio)
+// que atendem ao seletor de labels
+func (c *Client) ListPodMetrics(ctx context.Context, namespace, labelSelector string) ([]PodMetrics, error) {
+	var list struct {
+		Items []PodMetrics `json:"items"`
+	}
+	if err := c.get(ctx, namespacedPath("/apis/metrics.k8s.io/v1beta1", namespace, "pods"), selectorQuery("labelSelector", labelSelector), &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// Event é um evento do cluster, como Scheduled, Pulled ou Started de um pod
+type Event struct {
+	Metadata       ObjectMeta `json:"metadata"`
+	InvolvedObject struct {
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		FieldPath string `json:"fieldPath"`
+	} `json:"involvedObject"`
+	Reason         string     `json:"reason"`
+	Message        string     `json:"message"`
+	FirstTimestamp *time.Time `json:"firstTimestamp"`
+	LastTimestamp  *time.Time `json:"lastTimestamp"`
+	EventTime      *time.Time `json:"eventTime"` // microssegundos, preenchido pelos componentes mais novos
+}
+
+// Time retorna o instante do evento, preferindo a resolução maior de eventTime
+func (e Event) Time() time.Time {
+	switch {
+	case e.EventTime != nil && !e.EventTime.IsZero():
+		return *e.EventTime
+	case e.LastTimestamp != nil:
+		return *e.LastTimestamp
+	case e.FirstTimestamp != nil:
+		return *e.FirstTimestamp
+	}
+	return time.Time{}
+}
+
+// ListPodEvents lista os eventos de um pod
+func (c *Client) ListPodEvents(ctx context.Context, namespace, pod string) ([]Event, error) {
+	var list struct {
+		Items []Event `json:"items"`
+	}
+	fieldSelector := "involvedObject.kind=Pod,involvedObject.name=" + pod
+	if err := c.get(ctx, namespacedPath("/api/v1", namespace, "events"), selectorQuery("fieldSelector", fieldSelector), &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// selectorQuery monta a query string com um seletor, vazia quando o seletor é vazio
+func selectorQuery(name, selector string) url.Values {
+	if selector == "" {
+		return nil
+	}
+	return url.Values{name: {selector}}
+}
+
+// namespacedPath monta o caminho do recurso em um namespace ou em todos
+func namespacedPath(prefix, namespace, resource string) string {
+	if namespace == "" {
+		return prefix + "/" + resource
+	}
+	return prefix + "/namespaces/" + url.PathEscape(namespace) + "/" + resource
+}
+
+// get executa um GET no API server e decodifica a resposta JSON em out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	endpoint := c.config.Host + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if c.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.BearerToken)
+	} else if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach API server at %s: %w", c.config.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("API server returned status %d for %s: %s", resp.StatusCode, path, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+
+	return nil
+}
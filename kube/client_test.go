@@ -0,0 +1,191 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAPI responde às chamadas do cliente com o corpo associado a cada caminho e
+// registra as requisições recebidas
+type fakeAPI struct {
+	mu       sync.Mutex
+	bodies   map[string]string // caminho -> corpo JSON
+	requests []*http.Request
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	body, ok := f.bodies[r.URL.Path]
+	f.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(body))
+}
+
+// set troca o corpo retornado para o caminho
+func (f *fakeAPI) set(path, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bodies[path] = body
+}
+
+// last retorna a última requisição recebida
+func (f *fakeAPI) last() *http.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1]
+}
+
+// newTestClient cria o cliente apontando para o servidor de teste
+func newTestClient(t *testing.T, api http.Handler, config Config) *Client {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	config.Host = server.URL
+	return NewClient(&config)
+}
+
+func TestClientAuthHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{"token", Config{BearerToken: "abc"}, "Bearer abc"},
+		{"usuário e senha", Config{Username: "admin", Password: "secret"}, "Basic YWRtaW46c2VjcmV0"},
+		{"token tem prioridade", Config{BearerToken: "abc", Username: "admin"}, "Bearer abc"},
+		{"sem credenciais", Config{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{bodies: map[string]string{"/api/v1/pods": `{"items":[]}`}}
+			client := newTestClient(t, api, tt.config)
+
+			if _, err := client.ListPods(context.Background(), "", ""); err != nil {
+				t.Fatalf("ListPods: %v", err)
+			}
+			req := api.last()
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+			if got := req.Header.Get("Accept"); got != "application/json" {
+				t.Errorf("Accept = %q, want application/json", got)
+			}
+		})
+	}
+}
+
+func TestNamespacedPath(t *testing.T) {
+	tests := []struct {
+		namespace string
+		want      string
+	}{
+		{"", "/api/v1/pods"},
+		{"default", "/api/v1/namespaces/default/pods"},
+		{"a/b", "/api/v1/namespaces/a%2Fb/pods"},
+	}
+
+	for _, tt := range tests {
+		if got := namespacedPath("/api/v1", tt.namespace, "pods"); got != tt.want {
+			t.Errorf("namespacedPath(%q) = %s, want %s", tt.namespace, got, tt.want)
+		}
+	}
+}
+
+func TestClientListPods(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{
+		"/api/v1/namespaces/fn/pods": `{"items":[
+			{"metadata":{"name":"hello-1","namespace":"fn","labels":{"serving.knative.dev/service":"hello"},"creationTimestamp":"2024-01-01T00:00:00Z"},
+			 "spec":{"containers":[{"name":"user","resources":{"requests":{"cpu":"250m"}}}]},
+			 "status":{"phase":"Running"}}]}`,
+	}}
+	client := newTestClient(t, api, Config{})
+
+	pods, err := client.ListPods(context.Background(), "fn", "serving.knative.dev/service=hello")
+	if err != nil {
+		t.Fatalf("ListPods: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Metadata.Name != "hello-1" || pods[0].Spec.Containers[0].Resources.Requests["cpu"] != "250m" {
+		t.Errorf("pods = %+v", pods)
+	}
+	if got := api.last().URL.Query().Get("labelSelector"); got != "serving.knative.dev/service=hello" {
+		t.Errorf("labelSelector = %q", got)
+	}
+
+	// Sem seletor a query string fica vazia
+	if _, err := client.ListPods(context.Background(), "fn", ""); err != nil {
+		t.Fatalf("ListPods: %v", err)
+	}
+	if raw := api.last().URL.RawQuery; raw != "" {
+		t.Errorf("query = %q, want empty", raw)
+	}
+}
+
+func TestClientListPodMetrics(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{
+		"/apis/metrics.k8s.io/v1beta1/pods": `{"items":[
+			{"metadata":{"name":"hello-1","namespace":"fn"},"containers":[{"name":"user","usage":{"cpu":"12345678n","memory":"64Mi"}}]}]}`,
+	}}
+	client := newTestClient(t, api, Config{})
+
+	items, err := client.ListPodMetrics(context.Background(), "", "faas_function")
+	if err != nil {
+		t.Fatalf("ListPodMetrics: %v", err)
+	}
+	if len(items) != 1 || items[0].Containers[0].Usage["memory"] != "64Mi" {
+		t.Errorf("items = %+v", items)
+	}
+}
+
+func TestClientListPodEvents(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{
+		"/api/v1/namespaces/fn/events": `{"items":[
+			{"reason":"Pulled","message":"already present on machine","lastTimestamp":"2024-01-01T00:00:02Z","eventTime":"2024-01-01T00:00:01.500000Z"}]}`,
+	}}
+	client := newTestClient(t, api, Config{})
+
+	events, err := client.ListPodEvents(context.Background(), "fn", "hello-1")
+	if err != nil {
+		t.Fatalf("ListPodEvents: %v", err)
+	}
+	if got := api.last().URL.Query().Get("fieldSelector"); got != "involvedObject.kind=Pod,involvedObject.name=hello-1" {
+		t.Errorf("fieldSelector = %q", got)
+	}
+	if len(events) != 1 || events[0].Time().Nanosecond() != 500000000 {
+		t.Errorf("events = %+v, want eventTime preferred over lastTimestamp", events)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/pods", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `pods is forbidden: User "bench" cannot list resource "pods"`, http.StatusForbidden)
+	})
+	mux.HandleFunc("/api/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	})
+	client := newTestClient(t, mux, Config{})
+	ctx := context.Background()
+
+	if _, err := client.ListPodMetrics(ctx, "", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListPodMetrics on missing API = %v, want ErrNotFound", err)
+	}
+	if _, err := client.ListPods(ctx, "", ""); err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("ListPods with 403 = %v, want status and body in the error", err)
+	}
+	if _, err := client.ListPodEvents(ctx, "", "x"); err == nil || !strings.Contains(err.Error(), "decode") {
+		t.Errorf("ListPodEvents with invalid body = %v, want decode error", err)
+	}
+}
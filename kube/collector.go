@@ -0,0 +1,258 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+)
+
+// PlatformLabels identifica, por plataforma, o label do pod que guarda o nome da função
+var PlatformLabels = map[string]string{
+	"knative":   "serving.knative.dev/service",
+	"openwhisk": "whisk-managed",
+	"openfaas":  "faas_function",
+	"fission":   "fission-function-name",
+}
+
+// FunctionPod é um pod de função serverless identificado pelo label da plataforma
+type FunctionPod struct {
+	Pod
+	Platform string
+	Function string
+}
+
+// Active indica se o pod conta como réplica da função: não está sendo removido nem já terminou
+func (p FunctionPod) Active() bool {
+	return p.Metadata.DeletionTimestamp == nil && p.Status.Phase != "Succeeded" && p.Status.Phase != "Failed"
+}
+
+// Collector coleta as métricas dos pods de funções direto do API server, no lugar
+// do exporter em container. Com Platform ou Function vazios considera todos.
+type Collector struct {
+	Client    *Client
+	Platform  string
+	Function  string
+	Namespace string // vazio considera todos os namespaces
+
+	baseline *int
+}
+
+// NewCollector cria uma nova instância do Collector
+func NewCollector(client *Client, platform, function string) *Collector {
+	return &Collector{
+		Client:   client,
+		Platform: platform,
+		Function: function,
+	}
+}
+
+// Start registra a contagem inicial de pods, usada no cálculo de ScaledPodsDiff
+func (c *Collector) Start(ctx context.Context) error {
+	count, err := c.PodCount(ctx, c.Function)
+	if err != nil {
+		return err
+	}
+	c.baseline = &count
+	return nil
+}
+
+// FunctionPods lista os pods das funções, filtrados pela plataforma e pela função do coletor
+func (c *Collector) FunctionPods(ctx context.Context) ([]FunctionPod, error) {
+	return c.functionPods(ctx, c.Function)
+}
+
+// PodCount retorna o número de réplicas ativas da função
+func (c *Collector) PodCount(ctx context.Context, function string) (int, error) {
+	pods, err := c.functionPods(ctx, function)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, pod := range pods {
+		if pod.Active() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// PodNames retorna os nomes das réplicas ativas da função
+func (c *Collector) PodNames(ctx context.Context, function string) ([]string, error) {
+	pods, err := c.functionPods(ctx, function)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, pod := range pods {
+		if pod.Active() {
+			names = append(names, pod.Metadata.Name)
+		}
+	}
+	return names, nil
+}
+
+// Collect coleta o uso de CPU e memória, a variação de réplicas e o horário de
+// início dos containers dos pods da função, nos mesmos campos preenchidos pelo
+// exporter. A API só expõe os valores atuais; a janela seleciona apenas os pods
+// novos cuja inicialização é decomposta em fases.
+func (c *Collector) Collect(ctx context.Context, window metrics.Window) (metrics.ConsolidatedMetrics, error) {
+	collected := metrics.ConsolidatedMetrics{
+		PodStartedAt: make(map[string]float64),
+	}
+
+	pods, err := c.FunctionPods(ctx)
+	if err != nil {
+		return collected, err
+	}
+
+	usage, err := c.podUsage(ctx)
+	if err != nil {
+		return collected, err
+	}
+
+	active := 0
+	for _, pod := range pods {
+		if pod.Active() {
+			active++
+		}
+
+		key := pod.Metadata.Namespace + "/" + pod.Metadata.Name
+		if u, ok := usage[key]; ok {
+			collected.ClusterCPUUsage += u.cpu
+			collected.ClusterMemUsage += u.memory
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running != nil {
+				collected.PodStartedAt[pod.Metadata.Name] = float64(status.State.Running.StartedAt.UnixNano()) / 1e9
+				break
+			}
+		}
+	}
+
+	if c.baseline != nil {
+		collected.ScaledPodsDiff = active - *c.baseline
+	}
+
+	if window.Valid() {
+		collected.Startup = metrics.NewStartupBreakdown(c.PodStartups(ctx, pods, window))
+	}
+
+	return collected, nil
+}
+
+// Sample lê o uso de CPU, memória e o número de réplicas ativas da função.
+// Com function vazio considera todas as funções da plataforma.
+func (c *Collector) Sample(ctx context.Context, function string) (metrics.MetricSample, error) {
+	sample := metrics.MetricSample{Timestamp: time.Now()}
+
+	pods, err := c.functionPods(ctx, function)
+	if err != nil {
+		return sample, err
+	}
+
+	usage, err := c.podUsage(ctx)
+	if err != nil {
+		return sample, err
+	}
+
+	for _, pod := range pods {
+		if pod.Active() {
+			sample.Pods++
+		}
+		if u, ok := usage[pod.Metadata.Namespace+"/"+pod.Metadata.Name]; ok {
+			sample.CPU += u.cpu
+			sample.Memory += u.memory
+		}
+	}
+
+	return sample, nil
+}
+
+// resourceUsage é o uso somado dos containers de um pod
+type resourceUsage struct {
+	cpu    float64 // millicores
+	memory float64 // bytes
+}
+
+// podUsage lê o uso de recursos dos pods das funções, indexado por namespace/nome.
+// Sem o Metrics Server o uso fica vazio, como no exporter.
+func (c *Collector) podUsage(ctx context.Context) (map[string]resourceUsage, error) {
+	usage := make(map[string]resourceUsage)
+
+	for _, platform := range c.platforms() {
+		items, err := c.Client.ListPodMetrics(ctx, c.Namespace, selector(PlatformLabels[platform], c.Function))
+		if errors.Is(err, ErrNotFound) {
+			return usage, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			var u resourceUsage
+			for _, container := range item.Containers {
+				if cpu, err := ParseCPU(container.Usage["cpu"]); err == nil {
+					u.cpu += cpu
+				}
+				if memory, err := ParseMemory(container.Usage["memory"]); err == nil {
+					u.memory += memory
+				}
+			}
+			usage[item.Metadata.Namespace+"/"+item.Metadata.Name] = u
+		}
+	}
+
+	return usage, nil
+}
+
+// functionPods lista os pods com o label de função de cada plataforma considerada
+func (c *Collector) functionPods(ctx context.Context, function string) ([]FunctionPod, error) {
+	var result []FunctionPod
+
+	for _, platform := range c.platforms() {
+		label := PlatformLabels[platform]
+		pods, err := c.Client.ListPods(ctx, c.Namespace, selector(label, function))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s pods: %w", platform, err)
+		}
+
+		for _, pod := range pods {
+			result = append(result, FunctionPod{
+				Pod:      pod,
+				Platform: platform,
+				Function: pod.Metadata.Labels[label],
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// platforms retorna as plataformas consultadas, em ordem estável
+func (c *Collector) platforms() []string {
+	if _, ok := PlatformLabels[c.Platform]; ok {
+		return []string{c.Platform}
+	}
+
+	platforms := make([]string, 0, len(PlatformLabels))
+	for platform := range PlatformLabels {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// selector monta o seletor de labels: existência do label ou igualdade com a função
+func selector(label, function string) string {
+	if function == "" {
+		return label
+	}
+	return label + "=" + function
+}
@@ -0,0 +1,167 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+)
+
+// Pods da função hello no Knative: dois ativos, um sendo removido e um concluído
+const helloPods = `{"items":[
+	{"metadata":{"name":"hello-a","namespace":"fn","labels":{"serving.knative.dev/service":"hello"}},
+	 "status":{"phase":"Running","containerStatuses":[{"name":"user","state":{"running":{"startedAt":"2024-01-01T00:00:05Z"}}}]}},
+	{"metadata":{"name":"hello-b","namespace":"fn","labels":{"serving.knative.dev/service":"hello"}},
+	 "status":{"phase":"Pending"}},
+	{"metadata":{"name":"hello-c","namespace":"fn","labels":{"serving.knative.dev/service":"hello"},"deletionTimestamp":"2024-01-01T00:00:00Z"},
+	 "status":{"phase":"Running"}},
+	{"metadata":{"name":"hello-d","namespace":"fn","labels":{"serving.knative.dev/service":"hello"}},
+	 "status":{"phase":"Succeeded"}}]}`
+
+const helloPodMetrics = `{"items":[
+	{"metadata":{"name":"hello-a","namespace":"fn"},"containers":[
+		{"name":"user","usage":{"cpu":"100m","memory":"64Mi"}},
+		{"name":"queue-proxy","usage":{"cpu":"5000000n","memory":"16Mi"}}]},
+	{"metadata":{"name":"other","namespace":"fn"},"containers":[{"name":"user","usage":{"cpu":"1","memory":"1Gi"}}]}]}`
+
+// newTestCollector cria o coletor do Knative no namespace fn apontando para a API falsa
+func newTestCollector(t *testing.T, api *fakeAPI) *Collector {
+	t.Helper()
+	collector := NewCollector(newTestClient(t, api, Config{}), "knative", "hello")
+	collector.Namespace = "fn"
+	return collector
+}
+
+func TestCollectorPodCount(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{"/api/v1/namespaces/fn/pods": helloPods}}
+	collector := newTestCollector(t, api)
+
+	count, err := collector.PodCount(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("PodCount: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("PodCount = %d, want 2 active pods", count)
+	}
+	if got := api.last().URL.Query().Get("labelSelector"); got != "serving.knative.dev/service=hello" {
+		t.Errorf("labelSelector = %q", got)
+	}
+
+	names, err := collector.PodNames(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("PodNames: %v", err)
+	}
+	if len(names) != 2 || names[0] != "hello-a" || names[1] != "hello-b" {
+		t.Errorf("PodNames = %v, want [hello-a hello-b]", names)
+	}
+}
+
+func TestCollectorCollect(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{
+		"/api/v1/namespaces/fn/pods":                      `{"items":[]}`,
+		"/apis/metrics.k8s.io/v1beta1/namespaces/fn/pods": helloPodMetrics,
+	}}
+	collector := newTestCollector(t, api)
+	ctx := context.Background()
+
+	// A contagem de referência é lida com a função em zero
+	if err := collector.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	api.set("/api/v1/namespaces/fn/pods", helloPods)
+
+	collected, err := collector.Collect(ctx, metrics.Window{})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	if collected.ScaledPodsDiff != 2 {
+		t.Errorf("ScaledPodsDiff = %d, want 2", collected.ScaledPodsDiff)
+	}
+	// Apenas o uso dos pods da função, somado entre os containers
+	if collected.ClusterCPUUsage != 105 {
+		t.Errorf("ClusterCPUUsage = %g, want 105", collected.ClusterCPUUsage)
+	}
+	if collected.ClusterMemUsage != 80<<20 {
+		t.Errorf("ClusterMemUsage = %g, want %d", collected.ClusterMemUsage, 80<<20)
+	}
+	want := float64(time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC).Unix())
+	if got := collected.PodStartedAt["hello-a"]; got != want || len(collected.PodStartedAt) != 1 {
+		t.Errorf("PodStartedAt = %v, want hello-a at %g", collected.PodStartedAt, want)
+	}
+	if collected.Startup != nil {
+		t.Errorf("Startup = %+v, want nil without a window", collected.Startup)
+	}
+}
+
+func TestCollectorWithoutMetricsServer(t *testing.T) {
+	// Sem o metrics.k8s.io o uso fica zerado, mas réplicas continuam contadas
+	api := &fakeAPI{bodies: map[string]string{"/api/v1/namespaces/fn/pods": helloPods}}
+	collector := newTestCollector(t, api)
+
+	sample, err := collector.Sample(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Sample: %v", err)
+	}
+	if sample.Pods != 2 || sample.CPU != 0 || sample.Memory != 0 {
+		t.Errorf("Sample = %+v, want 2 pods without usage", sample)
+	}
+}
+
+func TestCollectorPodResources(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{"/api/v1/namespaces/fn/pods": `{"items":[
+		{"metadata":{"name":"hello-a","labels":{"serving.knative.dev/service":"hello"}},"status":{"phase":"Running"},
+		 "spec":{"containers":[
+			{"name":"user","resources":{"requests":{"cpu":"250m","memory":"128Mi"},"limits":{"memory":"256Mi"}}},
+			{"name":"queue-proxy","resources":{"requests":{"cpu":"25m","memory":"32Mi"}}}]}},
+		{"metadata":{"name":"hello-b","labels":{"serving.knative.dev/service":"hello"}},"status":{"phase":"Running"},
+		 "spec":{"containers":[
+			{"name":"user","resources":{"requests":{"cpu":"250m","memory":"128Mi"},"limits":{"memory":"256Mi"}}},
+			{"name":"queue-proxy","resources":{"requests":{"cpu":"75m","memory":"32Mi"}}}]}}]}`,
+	}}
+	collector := newTestCollector(t, api)
+
+	resources, err := collector.PodResources(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("PodResources: %v", err)
+	}
+
+	// O limit de memória não é declarado no queue-proxy, então fica desconhecido
+	want := metrics.PodResources{Pods: 2, CPURequest: 300, MemoryRequest: 160 << 20}
+	if resources != want {
+		t.Errorf("PodResources = %+v, want %+v", resources, want)
+	}
+}
+
+func TestCollectorStartupWindow(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{
+		"/api/v1/namespaces/fn/pods": `{"items":[
+			{"metadata":{"name":"before","labels":{"serving.knative.dev/service":"hello"},"creationTimestamp":"2024-01-01T00:00:09Z"},"status":{"phase":"Running"}},
+			{"metadata":{"name":"same-second","namespace":"fn","labels":{"serving.knative.dev/service":"hello"},"creationTimestamp":"2024-01-01T00:00:10Z"},
+			 "status":{"phase":"Running","conditions":[
+				{"type":"PodScheduled","status":"True","lastTransitionTime":"2024-01-01T00:00:11Z"},
+				{"type":"Ready","status":"True","lastTransitionTime":"2024-01-01T00:00:14Z"}]}},
+			{"metadata":{"name":"after","labels":{"serving.knative.dev/service":"hello"},"creationTimestamp":"2024-01-01T00:01:00Z"},"status":{"phase":"Running"}}]}`,
+		"/api/v1/namespaces/fn/events": `{"items":[{"reason":"Pulled","message":"Successfully pulled image","lastTimestamp":"2024-01-01T00:00:12Z"}]}`,
+	}}
+	collector := newTestCollector(t, api)
+
+	// A carga começa no meio do segundo em que o primeiro pod foi criado
+	start := time.Date(2024, 1, 1, 0, 0, 10, 400e6, time.UTC)
+	window := metrics.Window{Start: start, End: start.Add(30 * time.Second)}
+
+	pods, err := collector.FunctionPods(context.Background())
+	if err != nil {
+		t.Fatalf("FunctionPods: %v", err)
+	}
+	startups := collector.PodStartups(context.Background(), pods, window)
+
+	if len(startups) != 1 || startups[0].Pod != "same-second" {
+		t.Fatalf("startups = %+v, want only the pod created in the first second", startups)
+	}
+	s := startups[0]
+	if !s.Scheduled.Equal(time.Date(2024, 1, 1, 0, 0, 11, 0, time.UTC)) || !s.Pulled.Equal(time.Date(2024, 1, 1, 0, 0, 12, 0, time.UTC)) || s.ImageCached {
+		t.Errorf("startup = %+v", s)
+	}
+}
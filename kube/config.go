@@ -0,0 +1,249 @@
+package kube
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Caminhos das credenciais montadas nos pods pelo Kubernetes
+const (
+	serviceAccountToken = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCA    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Timeout padrão das chamadas ao API server
+const defaultRequestTimeout = 30 * time.Second
+
+// Config guarda o endereço do API server e as credenciais de acesso
+type Config struct {
+	Host        string // por exemplo https://10.0.0.1:6443
+	BearerToken string
+	Username    string
+	Password    string
+	TLS         *tls.Config
+}
+
+// LoadConfig carrega as credenciais como o exporter faz: primeiro as do pod
+// (in-cluster) e, fora do cluster, as do kubeconfig. Com kubeconfig vazio usa
+// a variável KUBECONFIG ou ~/.kube/config.
+func LoadConfig(kubeconfig string) (*Config, error) {
+	if kubeconfig == "" {
+		if config, err := InClusterConfig(); err == nil {
+			return config, nil
+		}
+	}
+
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+		// KUBECONFIG pode listar vários arquivos; apenas o primeiro é usado
+		if i := strings.IndexRune(kubeconfig, os.PathListSeparator); i != -1 {
+			kubeconfig = kubeconfig[:i]
+		}
+	}
+	if kubeconfig == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate kubeconfig: %w", err)
+		}
+		kubeconfig = filepath.Join(home, ".kube", "config")
+	}
+
+	return LoadKubeconfig(kubeconfig)
+}
+
+// InClusterConfig usa a conta de serviço do pod em que o benchmark está rodando
+func InClusterConfig() (*Config, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running inside a cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+
+	token, err := os.ReadFile(serviceAccountToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	ca, err := os.ReadFile(serviceAccountCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid service account CA certificate")
+	}
+
+	return &Config{
+		Host:        "https://" + net.JoinHostPort(host, port),
+		BearerToken: strings.TrimSpace(string(token)),
+		TLS:         &tls.Config{RootCAs: pool},
+	}, nil
+}
+
+// kubeconfig contém apenas os campos do arquivo usados pelo coletor
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			TLSServerName            string `yaml:"tls-server-name"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Username              string      `yaml:"username"`
+			Password              string      `yaml:"password"`
+			Exec                  interface{} `yaml:"exec"`
+			AuthProvider          interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// LoadKubeconfig lê o contexto atual do kubeconfig. Caminhos relativos de
+// certificados são resolvidos a partir do diretório do arquivo.
+func LoadKubeconfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+	}
+
+	var kc kubeconfig
+	if err := yaml.Unmarshal(data, &kc); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(file string) string {
+		if file == "" || filepath.IsAbs(file) {
+			return file
+		}
+		return filepath.Join(dir, file)
+	}
+
+	var clusterName, userName string
+	for _, c := range kc.Contexts {
+		if c.Name == kc.CurrentContext {
+			clusterName, userName = c.Context.Cluster, c.Context.User
+		}
+	}
+	if clusterName == "" {
+		return nil, fmt.Errorf("current context %q not found in kubeconfig %s", kc.CurrentContext, path)
+	}
+
+	config := &Config{TLS: &tls.Config{}}
+
+	found := false
+	for _, c := range kc.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		found = true
+		config.Host = strings.TrimSuffix(c.Cluster.Server, "/")
+		config.TLS.InsecureSkipVerify = c.Cluster.InsecureSkipTLSVerify
+		config.TLS.ServerName = c.Cluster.TLSServerName
+
+		ca, err := readData(c.Cluster.CertificateAuthorityData, resolve(c.Cluster.CertificateAuthority))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+		}
+		if ca != nil {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return nil, fmt.Errorf("invalid certificate authority for cluster %s", clusterName)
+			}
+			config.TLS.RootCAs = pool
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig %s", clusterName, path)
+	}
+
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+		user := u.User
+
+		if user.Exec != nil || user.AuthProvider != nil {
+			return nil, fmt.Errorf("user %s uses an exec or auth-provider plugin, which is not supported; use a token or client certificate", userName)
+		}
+
+		config.BearerToken = user.Token
+		if user.TokenFile != "" {
+			token, err := os.ReadFile(resolve(user.TokenFile))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read token file: %w", err)
+			}
+			config.BearerToken = strings.TrimSpace(string(token))
+		}
+		config.Username, config.Password = user.Username, user.Password
+
+		cert, err := readData(user.ClientCertificateData, resolve(user.ClientCertificate))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		key, err := readData(user.ClientKeyData, resolve(user.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+		if cert != nil && key != nil {
+			pair, err := tls.X509KeyPair(cert, key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid client certificate for user %s: %w", userName, err)
+			}
+			config.TLS.Certificates = []tls.Certificate{pair}
+		}
+	}
+
+	return config, nil
+}
+
+// readData retorna o conteúdo em base64 do campo *-data ou, na sua falta, o do arquivo
+func readData(encoded, file string) ([]byte, error) {
+	if encoded != "" {
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	return nil, nil
+}
+
+// httpClient cria o cliente HTTP com as configurações de TLS do cluster
+func (c *Config) httpClient() *http.Client {
+	return &http.Client{
+		Timeout: defaultRequestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: c.TLS,
+			Proxy:           http.ProxyFromEnvironment,
+		},
+	}
+}
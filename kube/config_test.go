@@ -0,0 +1,171 @@
+package kube
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeKubeconfig grava o kubeconfig em um diretório temporário e retorna o caminho
+func writeKubeconfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// kubeconfigFor monta um kubeconfig de um cluster e um usuário com o contexto atual
+func kubeconfigFor(server, cluster, user string) string {
+	return `apiVersion: v1
+kind: Config
+current-context: bench
+contexts:
+- name: other
+  context: {cluster: other, user: other}
+- name: bench
+  context: {cluster: bench, user: bench}
+clusters:
+- name: other
+  cluster: {server: "https://other.invalid"}
+- name: bench
+  cluster:
+    server: "` + server + `/"
+` + cluster + `
+users:
+- name: other
+  user: {token: wrong}
+- name: bench
+  user:
+` + user
+}
+
+func TestLoadKubeconfigTLSAndToken(t *testing.T) {
+	api := &fakeAPI{bodies: map[string]string{"/api/v1/pods": `{"items":[]}`}}
+	server := httptest.NewTLSServer(api)
+	defer server.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	path := writeKubeconfig(t, kubeconfigFor(server.URL,
+		"    certificate-authority-data: "+base64.StdEncoding.EncodeToString(ca),
+		"    token: secret-token"))
+
+	config, err := LoadKubeconfig(path)
+	if err != nil {
+		t.Fatalf("LoadKubeconfig: %v", err)
+	}
+	if config.Host != server.URL {
+		t.Errorf("Host = %s, want %s without trailing slash", config.Host, server.URL)
+	}
+
+	// O CA do kubeconfig é o único que valida o certificado do servidor de teste
+	if _, err := NewClient(config).ListPods(context.Background(), "", ""); err != nil {
+		t.Fatalf("ListPods over TLS: %v", err)
+	}
+	if got := api.last().Header.Get("Authorization"); got != "Bearer secret-token" {
+		t.Errorf("Authorization = %q", got)
+	}
+}
+
+func TestLoadKubeconfigUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    string
+		files   map[string]string
+		check   func(*testing.T, *Config)
+		wantErr string
+	}{
+		{
+			name:  "arquivo de token relativo ao kubeconfig",
+			user:  "    tokenFile: secrets/token",
+			files: map[string]string{"secrets/token": "file-token\n"},
+			check: func(t *testing.T, c *Config) {
+				if c.BearerToken != "file-token" {
+					t.Errorf("BearerToken = %q, want file-token", c.BearerToken)
+				}
+			},
+		},
+		{
+			name: "usuário e senha",
+			user: "    username: admin\n    password: secret",
+			check: func(t *testing.T, c *Config) {
+				if c.Username != "admin" || c.Password != "secret" || c.BearerToken != "" {
+					t.Errorf("credentials = %q/%q token %q", c.Username, c.Password, c.BearerToken)
+				}
+			},
+		},
+		{
+			name:    "plugin exec não é suportado",
+			user:    "    exec: {command: aws}",
+			wantErr: "exec or auth-provider",
+		},
+		{
+			name:    "certificado de cliente inválido",
+			user:    "    client-certificate-data: " + base64.StdEncoding.EncodeToString([]byte("x")) + "\n    client-key-data: " + base64.StdEncoding.EncodeToString([]byte("y")),
+			wantErr: "invalid client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeKubeconfig(t, kubeconfigFor("https://bench.invalid", "    insecure-skip-tls-verify: true", tt.user))
+			for name, content := range tt.files {
+				file := filepath.Join(filepath.Dir(path), name)
+				os.MkdirAll(filepath.Dir(file), 0o700)
+				if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			config, err := LoadKubeconfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadKubeconfig = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKubeconfig: %v", err)
+			}
+			if !config.TLS.InsecureSkipVerify {
+				t.Error("insecure-skip-tls-verify was not applied")
+			}
+			tt.check(t, config)
+		})
+	}
+}
+
+func TestLoadKubeconfigMissingContext(t *testing.T) {
+	path := writeKubeconfig(t, "current-context: nowhere\n")
+	if _, err := LoadKubeconfig(path); err == nil || !strings.Contains(err.Error(), `"nowhere"`) {
+		t.Errorf("LoadKubeconfig = %v, want missing context error", err)
+	}
+}
+
+func TestLoadConfigKubeconfigEnv(t *testing.T) {
+	// Fora do cluster o LoadConfig cai para o primeiro arquivo de KUBECONFIG
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+
+	path := writeKubeconfig(t, kubeconfigFor("https://bench.invalid", "", "    token: env-token"))
+	t.Setenv("KUBECONFIG", path+string(os.PathListSeparator)+filepath.Join(t.TempDir(), "missing"))
+
+	config, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if config.Host != "https://bench.invalid" || config.BearerToken != "env-token" {
+		t.Errorf("config = %s with token %q", config.Host, config.BearerToken)
+	}
+
+	// Um caminho explícito tem prioridade sobre KUBECONFIG
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadConfig with a missing explicit kubeconfig returned no error")
+	}
+}
@@ -0,0 +1,52 @@
+package kube
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Sufixos das quantidades do Kubernetes, em ordem para que "Ki" seja testado antes de "K"
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"n", 1e-9}, {"u", 1e-6}, {"m", 1e-3},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// ParseQuantity converte uma quantidade do Kubernetes ("250m", "128Mi", "1.5", "1e3") no seu valor
+func ParseQuantity(quantity string) (float64, error) {
+	q := strings.TrimSpace(quantity)
+	if q == "" {
+		return 0, fmt.Errorf("empty quantity")
+	}
+
+	multiplier := 1.0
+	for _, s := range quantitySuffixes {
+		if strings.HasSuffix(q, s.suffix) {
+			q, multiplier = strings.TrimSuffix(q, s.suffix), s.multiplier
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(q, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid quantity: %s", quantity)
+	}
+
+	return value * multiplier, nil
+}
+
+// ParseCPU converte uma quantidade de CPU em millicores
+func ParseCPU(quantity string) (float64, error) {
+	cores, err := ParseQuantity(quantity)
+	return cores * 1000, err
+}
+
+// ParseMemory converte uma quantidade de memória em bytes
+func ParseMemory(quantity string) (float64, error) {
+	return ParseQuantity(quantity)
+}
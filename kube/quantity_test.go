@@ -0,0 +1,67 @@
+package kube
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseCPU(t *testing.T) {
+	tests := []struct {
+		quantity string
+		want     float64 // millicores
+	}{
+		{"250m", 250},
+		{"1", 1000},
+		{"1.5", 1500},
+		{"12345678n", 12.345678},
+		{"500u", 0.5},
+		{"1e-1", 100},
+		{" 2 ", 2000},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCPU(tt.quantity)
+		if err != nil {
+			t.Errorf("ParseCPU(%q): %v", tt.quantity, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ParseCPU(%q) = %g, want %g", tt.quantity, got, tt.want)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		quantity string
+		want     float64 // bytes
+	}{
+		{"128Mi", 128 << 20},
+		{"1Gi", 1 << 30},
+		{"64Ki", 64 << 10},
+		{"1k", 1000},
+		{"1M", 1e6},
+		{"1G", 1e9},
+		{"123456", 123456},
+		{"1e3", 1000},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMemory(tt.quantity)
+		if err != nil {
+			t.Errorf("ParseMemory(%q): %v", tt.quantity, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMemory(%q) = %g, want %g", tt.quantity, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuantityInvalid(t *testing.T) {
+	for _, quantity := range []string{"", "   ", "Mi", "abc", "1.2.3m", "NaN", "Inf"} {
+		if _, err := ParseQuantity(quantity); err == nil {
+			t.Errorf("ParseQuantity(%q) returned no error", quantity)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package kube
+
+import (
+	"context"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+)
+
+// PodResources lê os requests e limits declarados nas réplicas ativas da função.
+// Cada recurso é a média, entre as réplicas que o declaram em todos os containers,
+// da soma dos containers do pod.
+func (c *Collector) PodResources(ctx context.Context, function string) (metrics.PodResources, error) {
+	var resources metrics.PodResources
+
+	pods, err := c.functionPods(ctx, function)
+	if err != nil {
+		return resources, err
+	}
+
+	var cpuRequest, cpuLimit, memoryRequest, memoryLimit resourceMean
+	for _, pod := range pods {
+		if !pod.Active() {
+			continue
+		}
+		resources.Pods++
+
+		containers := pod.Spec.Containers
+		cpuRequest.add(sumContainers(containers, func(c Container) string { return c.Resources.Requests["cpu"] }, ParseCPU))
+		cpuLimit.add(sumContainers(containers, func(c Container) string { return c.Resources.Limits["cpu"] }, ParseCPU))
+		memoryRequest.add(sumContainers(containers, func(c Container) string { return c.Resources.Requests["memory"] }, ParseMemory))
+		memoryLimit.add(sumContainers(containers, func(c Container) string { return c.Resources.Limits["memory"] }, ParseMemory))
+	}
+
+	resources.CPURequest = cpuRequest.mean()
+	resources.CPULimit = cpuLimit.mean()
+	resources.MemoryRequest = memoryRequest.mean()
+	resources.MemoryLimit = memoryLimit.mean()
+
+	return resources, nil
+}
+
+// sumContainers soma a quantidade lida de cada container do pod. Retorna false
+// quando algum container não declara a quantidade.
+func sumContainers(containers []Container, quantity func(Container) string, parse func(string) (float64, error)) (float64, bool) {
+	if len(containers) == 0 {
+		return 0, false
+	}
+
+	total := 0.0
+	for _, container := range containers {
+		value, err := parse(quantity(container))
+		if err != nil {
+			return 0, false
+		}
+		total += value
+	}
+	return total, true
+}
+
+// resourceMean acumula a média de um recurso entre as réplicas que o declaram
+type resourceMean struct {
+	sum   float64
+	count int
+}
+
+func (m *resourceMean) add(value float64, ok bool) {
+	if ok {
+		m.sum += value
+		m.count++
+	}
+}
+
+func (m resourceMean) mean() float64 {
+	if m.count == 0 {
+		return 0
+	}
+	return m.sum / float64(m.count)
+}
@@ -0,0 +1,31 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// O coletor é registrado como a fonte kubernetes; basta importar o pacote
+func init() {
+	metrics.RegisterSource(parameters.MetricsKubernetes, newSource)
+}
+
+// newSource cria o coletor do cenário com as credenciais de metrics.kubeconfig e
+// registra a contagem inicial de pods antes da carga
+func newSource(ctx context.Context, params *parameters.BenchmarkParameters) (metrics.MetricsSource, error) {
+	config, err := LoadConfig(params.Metrics.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubernetes credentials: %w", err)
+	}
+
+	collector := NewCollector(NewClient(config), params.Platform, params.Function)
+	collector.Namespace = params.Namespace
+	if err := collector.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list function pods: %w", err)
+	}
+
+	return collector, nil
+}
@@ -0,0 +1,92 @@
+package kube
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+)
+
+// PodStartups decompõe a inicialização dos pods criados dentro da janela, a
+// partir das condições de cada pod e dos seus eventos Pulled. Sem acesso aos
+// eventos as fases que dependem da imagem ficam desconhecidas.
+func (c *Collector) PodStartups(ctx context.Context, pods []FunctionPod, window metrics.Window) []metrics.PodStartup {
+	// creationTimestamp é truncado em segundos; sem truncar o início, o pod criado
+	// no mesmo segundo em que a carga começou, em geral o primeiro cold start,
+	// ficaria de fora
+	start := window.Start.Truncate(time.Second)
+
+	var startups []metrics.PodStartup
+	for _, pod := range pods {
+		created := pod.Metadata.CreationTimestamp
+		if created.Before(start) || created.After(window.End) {
+			continue
+		}
+
+		startup := podStartup(pod.Pod)
+		events, err := c.Client.ListPodEvents(ctx, pod.Metadata.Namespace, pod.Metadata.Name)
+		if err != nil {
+			startup.Error = err.Error()
+		} else {
+			applyPullEvents(&startup, events)
+		}
+		startups = append(startups, startup)
+	}
+	return startups
+}
+
+// podStartup lê os instantes de criação, agendamento, início dos containers e
+// prontidão do pod
+func podStartup(pod Pod) metrics.PodStartup {
+	startup := metrics.PodStartup{
+		Pod:     pod.Metadata.Name,
+		Created: pod.Metadata.CreationTimestamp,
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Status != "True" {
+			continue
+		}
+		switch condition.Type {
+		case "PodScheduled":
+			startup.Scheduled = condition.LastTransitionTime
+		case "Ready":
+			startup.Ready = condition.LastTransitionTime
+		}
+	}
+
+	// O pod só está iniciado quando todos os containers estão em execução
+	for _, status := range pod.Status.ContainerStatuses {
+		running := status.State.Running
+		if running == nil {
+			startup.Started = time.Time{}
+			break
+		}
+		if running.StartedAt.After(startup.Started) {
+			startup.Started = running.StartedAt
+		}
+	}
+
+	return startup
+}
+
+// applyPullEvents registra o último evento Pulled dos containers do pod. A imagem
+// estava em cache quando todos os eventos informam que ela já estava no nó.
+func applyPullEvents(startup *metrics.PodStartup, events []Event) {
+	pulls := 0
+	cached := 0
+	for _, event := range events {
+		if event.Reason != "Pulled" {
+			continue
+		}
+		pulls++
+		if strings.Contains(event.Message, "already present on machine") {
+			cached++
+		}
+		if t := event.Time(); t.After(startup.Pulled) {
+			startup.Pulled = t
+		}
+	}
+	startup.ImageCached = pulls > 0 && cached == pulls
+}
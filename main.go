@@ -6,116 +6,490 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/mariaisadora-github/FaaSKubeBench/experiments"
 	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	_ "github.com/mariaisadora-github/FaaSKubeBench/kube" // registra a fonte de métricas kubernetes
 	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
 	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+	"github.com/mariaisadora-github/FaaSKubeBench/report"
 )
 
-// Constantes para o exporter
-const (
-	ExporterPort = "8000"
-	ExporterURL  = "http://localhost:" + ExporterPort + "/metrics"
-)
+// Caminho padrão do relatório em Markdown
+const DefaultReportPath = "relatorio_benchmark.md"
 
 func main() {
 	// 1. Tratamento de Argumentos de Linha de Comando
 	if len(os.Args) < 2 {
-		log.Fatal("Usage: faaskubebench <path-to-config.yaml>")
+		log.Fatal("Usage: faaskubebench <path-to-config.yaml> [report.md]")
 	}
 
 	configPath := os.Args[1]
 
-	// 2. Carregar Parâmetros
-	params, err := parameters.LoadParametersFromFile(configPath)
+	reportPath := DefaultReportPath
+	if len(os.Args) > 2 {
+		reportPath = os.Args[2]
+	}
+
+	// 2. Carregar Parâmetros (um conjunto por cenário e ponto de varredura)
+	allParams, err := parameters.LoadParametersFromFile(configPath)
 	if err != nil {
 		log.Fatalf("Erro ao carregar os parâmetros do arquivo %s: %v", configPath, err)
 	}
 
+	// Confere as fontes de métricas antes de iniciar qualquer cenário
+	if err := checkSources(allParams); err != nil {
+		log.Fatalf("%v", err)
+	}
+
 	fmt.Println("\n" + strings.Repeat("=", 80))
 	fmt.Println("   INICIANDO BENCHMARK FAASKUBEBENCH")
 	fmt.Println(strings.Repeat("=", 80))
+	if len(allParams) > 1 {
+		fmt.Printf(" Cenários e pontos de varredura: %d\n", len(allParams))
+	}
 
 	// --- Orquestração do Benchmark ---
 
-	// 3. Iniciar Exporter de Métricas (Docker Compose)
-	fmt.Println(" Iniciando Exporter de Métricas...")
+	// 3. Iniciar Exporter de Métricas (Docker Compose), apenas se algum cenário o utiliza;
+	// com metrics.source kubernetes as métricas vêm direto da API do cluster
+	if usesExporter(allParams) {
+		fmt.Println(" Iniciando Exporter de Métricas...")
 
-	// Define o tempo de início do benchmark ANTES de iniciar o exporter
-	benchmarkStartTime := time.Now().UTC()
-	benchmarkStartTimeStr := benchmarkStartTime.Format(time.RFC3339Nano)
+		// O exporter atualiza as métricas no menor intervalo de amostragem configurado
+		os.Setenv("COLLECTION_INTERVAL_SECONDS", exporterInterval(allParams))
+
+		// CORREÇÃO: Usar "docker compose" (novo padrão)
+		exporterCmd := exec.Command("docker", "compose", "up", "-d")
+		// Manter o stdout/stderr para o caso de erro, mas sem logs de sucesso
+		exporterCmd.Stdout = os.Stdout
+		exporterCmd.Stderr = os.Stderr
+
+		if err := exporterCmd.Run(); err != nil {
+			log.Fatalf("Erro ao iniciar o exporter de métricas (Verifique se o Docker e o plugin 'compose' estão instalados e o docker-compose.yaml está na pasta): %v", err)
+		}
+		time.Sleep(5 * time.Second) // Aguarda estabilização
+		fmt.Println(" Exporter iniciado com sucesso")
+		fmt.Println()
+
+		// Garante que o exporter será parado ao final, mesmo em caso de erro
+		defer func() {
+			fmt.Println("\n Encerrando exporter de métricas...")
+			stopCmd := exec.Command("docker", "compose", "down")
+			// Suprimir a saída de sucesso do 'down'
+			stopCmd.Stdout = nil
+			stopCmd.Stderr = nil
+			if err := stopCmd.Run(); err != nil {
+				log.Printf("Aviso: Erro ao parar o exporter de métricas: %v", err)
+			}
+		}()
+	}
+
+	// 4. Executar cada cenário em sequência
+	allResults := make([]metrics.ConsolidatedMetrics, 0, len(allParams))
+	for i, params := range allParams {
+		if label := params.Label(); label != "" {
+			fmt.Printf("\n Cenário %d/%d: %s\n", i+1, len(allParams), label)
+		}
+
+		finalReportData, err := runBenchmark(params)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+
+		printResults(finalReportData)
+		allResults = append(allResults, finalReportData)
+	}
+
+	// 5. Gerar o relatório em Markdown (comparativo quando há mais de um cenário)
+	if len(allResults) > 1 {
+		err = report.NewComparisonReportGenerator(allResults).Generate(reportPath)
+	} else {
+		err = report.NewReportGenerator(allResults[0]).Generate(reportPath)
+	}
+	if err != nil {
+		log.Printf("Aviso: Erro ao gerar o relatório: %v", err)
+	} else {
+		fmt.Printf("\n Relatório salvo em %s\n", reportPath)
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println(" Benchmark concluído com sucesso!")
+	fmt.Println(strings.Repeat("=", 80) + "\n")
+}
+
+// collectWindow coleta as métricas do cluster sobre a janela das execuções, quando
+// houver, ou do início do cenário até agora
+func collectWindow(ctx context.Context, source metrics.MetricsSource, runs []*heyexec.RunResult, scenarioStart time.Time) (metrics.ConsolidatedMetrics, error) {
+	window, ok := metrics.RunWindow(runs)
+	if !ok {
+		window = metrics.Window{Start: scenarioStart, End: time.Now()}
+	}
+	return source.Collect(ctx, window)
+}
+
+// newMetricsSource cria a fonte de métricas configurada e avisa quando ela não lê a
+// especificação dos pods, pois a utilização relativa a requests e limits fica N/A
+func newMetricsSource(ctx context.Context, params *parameters.BenchmarkParameters) (metrics.MetricsSource, error) {
+	source, err := metrics.NewSource(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar a fonte de métricas %s: %v", params.Metrics.Source, err)
+	}
+
+	_, none := source.(metrics.NoneSource)
+	if _, ok := source.(metrics.ResourceReader); !ok && !none {
+		log.Printf("Aviso: a fonte de métricas %s não lê os requests e limits dos pods; a utilização relativa a eles ficará N/A (use a fonte kubernetes ou prometheus)", params.Metrics.Source)
+	}
+
+	return source, nil
+}
+
+// startSampler inicia as leituras contínuas do cluster no intervalo configurado
+func startSampler(ctx context.Context, source metrics.MetricsSource, params *parameters.BenchmarkParameters) *metrics.Sampler {
+	interval, _ := time.ParseDuration(params.Metrics.SampleInterval)
+	sampler := metrics.NewSampler(interval, func(ctx context.Context) (metrics.MetricSample, error) {
+		return source.Sample(ctx, params.Function)
+	})
+	sampler.Start(ctx)
+	return sampler
+}
 
-	os.Setenv("BENCHMARK_START_TIME", benchmarkStartTimeStr)
+// observeScaleDown mantém as leituras contínuas depois da última execução até as
+// réplicas voltarem à contagem de antes dela ou a zero, limitado por
+// metrics.scale_down_timeout
+func observeScaleDown(ctx context.Context, params *parameters.BenchmarkParameters, source metrics.MetricsSource, tracker *metrics.ExecutionTracker, sampler *metrics.Sampler, runs []*heyexec.RunResult) {
+	timeout, _ := time.ParseDuration(params.Metrics.ScaleDownTimeout)
+	if _, none := source.(metrics.NoneSource); none || timeout == 0 || len(runs) == 0 {
+		return
+	}
+	last := runs[len(runs)-1]
+	if last == nil || last.StartTime.IsZero() {
+		return
+	}
+
+	// Referência da última execução: o snapshot de antes dela ou, sem ele, a
+	// última leitura contínua anterior ao seu início
+	baseline := -1
+	if snap := tracker.Snapshots()[len(runs)]; snap != nil && snap.Error == "" {
+		baseline = snap.Before.Pods
+	} else {
+		for _, sample := range sampler.Samples() {
+			if sample.Timestamp.Before(last.StartTime) {
+				baseline = sample.Pods
+			}
+		}
+	}
+
+	fmt.Printf("\n Observando o retorno das réplicas (até %s)...\n", timeout)
+	reached, err := experiments.WaitForScaleDown(ctx, params, source, baseline)
+	switch {
+	case err != nil:
+		fmt.Printf(" Retorno das réplicas não observado: %v\n", err)
+	case !reached:
+		fmt.Printf(" As réplicas não voltaram em %s\n", timeout)
+	default:
+		fmt.Println(" Réplicas de volta à referência")
+	}
+}
+
+// stopSampler encerra as leituras contínuas e resume a série coletada
+func stopSampler(ctx context.Context, sampler *metrics.Sampler) *metrics.SampledMetrics {
+	samples := sampler.Stop(ctx)
+	return metrics.NewSampledMetrics(sampler.Interval, samples, sampler.Errors())
+}
 
-	// CORREÇÃO: Usar "docker compose" (novo padrão)
-	exporterCmd := exec.Command("docker", "compose", "up", "-d")
-	// Manter o stdout/stderr para o caso de erro, mas sem logs de sucesso
-	exporterCmd.Stdout = os.Stdout
-	exporterCmd.Stderr = os.Stderr
+// checkSources verifica se a fonte de métricas de cada cenário está registrada
+func checkSources(allParams []*parameters.BenchmarkParameters) error {
+	names := metrics.SourceNames()
+	for _, params := range allParams {
+		if !slices.Contains(names, params.Metrics.Source) {
+			return fmt.Errorf("fonte de métricas não suportada: %s. Fontes disponíveis: %s",
+				params.Metrics.Source, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
 
-	if err := exporterCmd.Run(); err != nil {
-		log.Fatalf("Erro ao iniciar o exporter de métricas (Verifique se o Docker e o plugin 'compose' estão instalados e o docker-compose.yaml está na pasta): %v", err)
+// usesExporter indica se algum cenário coleta as métricas pelo exporter
+func usesExporter(allParams []*parameters.BenchmarkParameters) bool {
+	for _, params := range allParams {
+		if params.Metrics.Source == parameters.MetricsExporter {
+			return true
+		}
 	}
-	time.Sleep(5 * time.Second) // Aguarda estabilização
-	fmt.Println(" Exporter iniciado com sucesso\n")
+	return false
+}
 
-	// Garante que o exporter será parado ao final, mesmo em caso de erro
-	defer func() {
-		fmt.Println("\n Encerrando exporter de métricas...")
-		stopCmd := exec.Command("docker", "compose", "down")
-		// Suprimir a saída de sucesso do 'down'
-		stopCmd.Stdout = nil
-		stopCmd.Stderr = nil
-		if err := stopCmd.Run(); err != nil {
-			log.Printf("Aviso: Erro ao parar o exporter de métricas: %v", err)
+// exporterInterval retorna, em segundos, o menor intervalo de amostragem dos cenários
+// que usam o exporter, com no mínimo um segundo
+func exporterInterval(allParams []*parameters.BenchmarkParameters) string {
+	interval := time.Duration(0)
+	for _, params := range allParams {
+		d, _ := time.ParseDuration(params.Metrics.SampleInterval)
+		if params.Metrics.Source == parameters.MetricsExporter && (interval == 0 || d < interval) {
+			interval = d
 		}
-	}()
+	}
+	return fmt.Sprintf("%g", max(interval, time.Second).Seconds())
+}
 
-	// 4. Executar o Benchmark (Hey)
+// runBenchmark executa o gerador de carga para um conjunto de parâmetros,
+// coleta as métricas do cluster e consolida os resultados
+func runBenchmark(params *parameters.BenchmarkParameters) (metrics.ConsolidatedMetrics, error) {
+	switch params.Mode {
+	case parameters.ModeColdStart:
+		return runColdStart(params)
+	case parameters.ModeKeepAlive:
+		return runKeepAlive(params)
+	}
+
+	benchmarkStartTime := time.Now().UTC()
+	ctx := context.Background()
+
+	source, err := newMetricsSource(ctx, params)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, err
+	}
+
+	// Leituras contínuas do cluster do início ao fim da carga
+	sampler := startSampler(ctx, source, params)
+
+	// Executar o Benchmark (Hey)
 	fmt.Println(" Executando Gerador de Carga...")
 	heyExecutor := heyexec.NewHeyExecutor(params)
 
+	// Snapshots da função logo antes e logo depois de cada execução
+	tracker := metrics.NewExecutionTracker(source, params.Function)
+	heyExecutor.Observer = tracker
+
+	// Pausa entre execuções conforme a política configurada
+	heyExecutor.Cooldown, err = experiments.NewCooldownPolicy(ctx, params, source)
+	if err != nil {
+		sampler.Stop(ctx)
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro ao preparar a pausa entre execuções: %v", err)
+	}
+
 	// Executa o hey
 	allHeyResults, err := heyExecutor.ExecuteMultiple()
 	if err != nil {
-		log.Fatalf("Erro durante a execução do gerador de carga: %v", err)
+		sampler.Stop(ctx)
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro durante a execução do gerador de carga: %v", err)
 	}
 
-	// 5. Coletar Métricas do Exporter
-	fmt.Println("\n Coletando Métricas do Prometheus...")
+	// Leituras até as réplicas voltarem, para medir o retorno da última execução
+	scaleDownStart := time.Now()
+	observeScaleDown(ctx, params, source, tracker, sampler, allHeyResults)
+	sampled := stopSampler(ctx, sampler).SplitScaleDown(scaleDownStart)
 
-	// Cria o cliente para coletar as métricas do Prometheus do exporter
-	postProcessor := metrics.NewPostProcessor(ExporterURL)
+	// Coletar Métricas do Cluster
+	fmt.Println("\n Coletando Métricas do Cluster...")
 
-	// Coleta as métricas do exporter (started_at, contagem de pods, CPU/Memória do cluster)
-	collectedMetrics, err := postProcessor.CollectMetrics(context.Background())
+	// Coleta as métricas do cluster (started_at, contagem de pods, CPU/Memória)
+	collectedMetrics, err := collectWindow(ctx, source, allHeyResults, benchmarkStartTime)
 	if err != nil {
-		log.Fatalf("Erro ao coletar métricas do exporter: %v", err)
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro ao coletar métricas do cluster: %v", err)
 	}
 
-	// 6. Pós-processamento e Consolidação
+	// Sem série da própria fonte, usa as leituras contínuas feitas durante a carga;
+	// as leituras do retorno das réplicas vêm sempre do amostrador
+	if collectedMetrics.Sampled == nil {
+		collectedMetrics.Sampled = sampled
+	} else if collectedMetrics.Sampled.ScaleDown == nil {
+		collectedMetrics.Sampled.ScaleDown = sampled.ScaleDown
+	}
+
+	postProcessor := metrics.NewPostProcessor()
+	postProcessor.SetPercentiles(params.Percentiles)
+	postProcessor.SetSnapshots(tracker.Snapshots())
+	postProcessor.SetClusters(tracker.Clusters())
+	postProcessor.SetPricing(params.Cost)
+
+	// Pós-processamento e Consolidação
 	fmt.Println(" Processando e consolidando resultados...")
 
 	// Consolida os resultados do hey e as métricas coletadas
 	finalReportData := postProcessor.ConsolidateResults(allHeyResults, collectedMetrics, benchmarkStartTime)
+	finalReportData.Label = params.Label()
+
+	return finalReportData, nil
+}
+
+// runColdStart executa as sondagens de cold start, sempre com a função escalada
+// para zero, e consolida a distribuição das latências com as métricas do cluster
+func runColdStart(params *parameters.BenchmarkParameters) (metrics.ConsolidatedMetrics, error) {
+	benchmarkStartTime := time.Now().UTC()
+	ctx := context.Background()
+
+	source, err := newMetricsSource(ctx, params)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, err
+	}
 
-	// 7. Exibir Resultados na Tela
+	fmt.Println(" Executando Sondagens de Cold Start...")
+	probe := experiments.NewColdStartProbe(params, func(ctx context.Context) (int, error) {
+		return source.PodCount(ctx, params.Function)
+	})
+
+	sampler := startSampler(ctx, source, params)
+	samples, err := probe.Run(ctx)
+	sampled := stopSampler(ctx, sampler)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro durante as sondagens de cold start: %v", err)
+	}
+
+	fmt.Println("\n Coletando Métricas do Cluster...")
+	collectedMetrics, err := collectWindow(ctx, source, nil, benchmarkStartTime)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro ao coletar métricas do cluster: %v", err)
+	}
+
+	postProcessor := metrics.NewPostProcessor()
+	postProcessor.SetPercentiles(params.Percentiles)
+	finalReportData := postProcessor.ConsolidateResults(nil, collectedMetrics, benchmarkStartTime)
+	finalReportData.Label = params.Label()
+	finalReportData.ColdStart = metrics.NewColdStartMetrics(params.Platform, params.Function, samples, params.Percentiles)
+	if finalReportData.Sampled == nil {
+		finalReportData.Sampled = sampled
+	}
+
+	return finalReportData, nil
+}
+
+// runKeepAlive busca a janela de keep-alive da função e consolida a estimativa
+// com as métricas do cluster
+func runKeepAlive(params *parameters.BenchmarkParameters) (metrics.ConsolidatedMetrics, error) {
+	benchmarkStartTime := time.Now().UTC()
+	ctx := context.Background()
+
+	source, err := newMetricsSource(ctx, params)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, err
+	}
+
+	fmt.Printf(" Buscando a Janela de Keep-Alive (%s)...\n", params.KeepAlive.Strategy)
+	search := experiments.NewKeepAliveSearch(params, func(ctx context.Context) (int, error) {
+		return source.PodCount(ctx, params.Function)
+	})
+
+	sampler := startSampler(ctx, source, params)
+	keepAlive, err := search.Run(ctx)
+	sampled := stopSampler(ctx, sampler)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro durante a busca da janela de keep-alive: %v", err)
+	}
+
+	fmt.Println("\n Coletando Métricas do Cluster...")
+	collectedMetrics, err := collectWindow(ctx, source, nil, benchmarkStartTime)
+	if err != nil {
+		return metrics.ConsolidatedMetrics{}, fmt.Errorf("erro ao coletar métricas do cluster: %v", err)
+	}
+
+	postProcessor := metrics.NewPostProcessor()
+	postProcessor.SetPercentiles(params.Percentiles)
+	finalReportData := postProcessor.ConsolidateResults(nil, collectedMetrics, benchmarkStartTime)
+	finalReportData.Label = params.Label()
+	finalReportData.KeepAlive = keepAlive
+	if finalReportData.Sampled == nil {
+		finalReportData.Sampled = sampled
+	}
+
+	return finalReportData, nil
+}
+
+// printResults exibe os resultados consolidados na tela
+func printResults(finalReportData metrics.ConsolidatedMetrics) {
 	fmt.Println("\n" + strings.Repeat("=", 80))
 	fmt.Println("                      RELATÓRIO DE BENCHMARK FAASKUBEBENCH")
+	if finalReportData.Label != "" {
+		fmt.Printf("   %s\n", finalReportData.Label)
+	}
 	fmt.Println(strings.Repeat("=", 80))
 
-	fmt.Println("\n MÉTRICAS DO GERADOR DE CARGA")
-	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf("   Requisições por Segundo (RPS):     %.2f req/s\n", finalReportData.RPS)
-	fmt.Printf("    Latência Média:                     %.4f s (%.2f ms)\n",
-		finalReportData.AvgLatency, finalReportData.AvgLatency*1000)
-	fmt.Printf("   Latência de Cauda (p99):            %.4f s (%.2f ms)\n",
-		finalReportData.P99Latency, finalReportData.P99Latency*1000)
-	fmt.Printf("   Total de Requisições:               %d\n", finalReportData.TotalRequests)
-	fmt.Printf("   Taxa de Erros HTTP (4xx/5xx):       %.2f%%\n", finalReportData.ErrorRate*100)
-	fmt.Printf("   Tráfego de Dados Total:             %.2f MB\n", float64(finalReportData.TotalData)/(1024*1024))
+	if len(finalReportData.Executions) > 0 {
+		fmt.Println("\n MÉTRICAS DO GERADOR DE CARGA")
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Requisições por Segundo (RPS):     %.2f req/s\n", finalReportData.RPS)
+		fmt.Printf("    Latência Média:                     %.4f s (%.2f ms)\n",
+			finalReportData.AvgLatency, finalReportData.AvgLatency*1000)
+		fmt.Printf("   Latência de Cauda (p99):            %.4f s (%.2f ms)\n",
+			finalReportData.P99Latency, finalReportData.P99Latency*1000)
+		fmt.Printf("   Total de Requisições:               %d\n", finalReportData.TotalRequests)
+		fmt.Printf("   Taxa de Erros HTTP (4xx/5xx):       %.2f%%\n", finalReportData.ErrorRate*100)
+		fmt.Printf("   Tráfego de Dados Total:             %.2f MB\n", float64(finalReportData.TotalData)/(1024*1024))
+
+		for _, pct := range finalReportData.PooledPercentiles {
+			fmt.Printf("   Latência p%-6g                      %.4f s (%.2f ms)\n", pct.Percentage*100, pct.Latency, pct.Latency*1000)
+		}
+	}
+
+	if cw := finalReportData.ColdWarm; cw != nil {
+		fmt.Println("\n REQUISIÇÕES FRIAS E QUENTES")
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Frias:                              %d (%.2f%%), média %.2f ms\n", cw.Cold.Count, cw.Cold.Percentage*100, cw.Cold.Mean*1000)
+		fmt.Printf("   Quentes:                            %d (%.2f%%), média %.2f ms\n", cw.Warm.Count, cw.Warm.Percentage*100, cw.Warm.Mean*1000)
+		if fit := cw.Bimodal; fit != nil {
+			fmt.Printf("   Modos (rápido / lento):             %.2f ms / %.2f ms\n", fit.WarmLatency*1000, fit.ColdLatency*1000)
+		}
+	}
+
+	if cs := finalReportData.ColdStart; cs != nil {
+		fmt.Printf("\n COLD START (%s)\n", cs.Platform)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Sondagens Válidas:                  %d/%d\n", cs.Latency.N, len(cs.Samples))
+		fmt.Printf("   Latência (média ± desvio):          %.2f ms ± %.2f ms\n", cs.Latency.Mean*1000, cs.Latency.StdDev*1000)
+		fmt.Printf("   Latência (mín/mediana/máx):         %.2f / %.2f / %.2f ms\n",
+			cs.Latency.Min*1000, cs.Latency.Median*1000, cs.Latency.Max*1000)
+		for _, pct := range cs.Percentiles {
+			fmt.Printf("   Latência p%-6g                      %.4f s (%.2f ms)\n", pct.Percentage*100, pct.Latency, pct.Latency*1000)
+		}
+	}
+
+	if ol := finalReportData.OpenLoop; ol != nil {
+		fmt.Println("\n MODO OPEN (latência a partir do horário planejado)")
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Tempo de Resposta (média/p99):      %.2f ms / %.2f ms\n", ol.AvgResponseTime*1000, ol.P99ResponseTime*1000)
+		fmt.Printf("   Tempo de Serviço (média/p99):       %.2f ms / %.2f ms\n", ol.AvgServiceTime*1000, ol.P99ServiceTime*1000)
+		fmt.Printf("   Atraso de Envio (média/máximo):     %.2f ms / %.2f ms\n", ol.AvgSchedulingDelay*1000, ol.MaxSchedulingDelay*1000)
+	}
+
+	if ka := finalReportData.KeepAlive; ka != nil {
+		fmt.Printf("\n JANELA DE KEEP-ALIVE (%s, %s)\n", ka.Platform, ka.Strategy)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Latência de Referência:             %.2f ms\n", ka.WarmLatency*1000)
+		fmt.Printf("   Sondagens:                          %d\n", len(ka.Probes))
+		if ka.Found {
+			fmt.Printf("   Janela Estimada:                    %s (entre %s e %s)\n", ka.Window, ka.LastWarmGap, ka.FirstColdGap)
+		} else {
+			fmt.Printf("   Janela Estimada:                    maior que %s (nenhuma sondagem fria)\n", ka.LastWarmGap)
+		}
+	}
+
+	if len(finalReportData.Stages) > 0 {
+		fmt.Println("\n ESTÁGIOS DO PERFIL DE CARGA")
+		fmt.Println(strings.Repeat("-", 80))
+		for _, st := range finalReportData.Stages {
+			fmt.Printf("   %d. %-10s alvo %8.2f req/s | atingido %8.2f req/s | p99 %.2f ms\n",
+				st.Index+1, st.Type, st.TargetRPS, st.AchievedRPS.Mean, st.P99Latency*1000)
+		}
+	}
+
+	if len(finalReportData.Executions) > 1 {
+		agg := finalReportData.Aggregates
+		fmt.Println("\n ESTATÍSTICAS ENTRE EXECUÇÕES (média ± desvio padrão, IC 95%)")
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Execuções bem-sucedidas:            %d/%d\n", agg.RPS.N, len(finalReportData.Executions))
+		fmt.Printf("   RPS:                                %.2f ± %.2f [%.2f, %.2f]\n",
+			agg.RPS.Mean, agg.RPS.StdDev, agg.RPS.CI95Low, agg.RPS.CI95High)
+		fmt.Printf("   Latência Média (ms):                %.2f ± %.2f [%.2f, %.2f]\n",
+			agg.AvgLatency.Mean*1000, agg.AvgLatency.StdDev*1000, agg.AvgLatency.CI95Low*1000, agg.AvgLatency.CI95High*1000)
+		fmt.Printf("   Latência p99 (ms):                  %.2f ± %.2f [%.2f, %.2f]\n",
+			agg.P99Latency.Mean*1000, agg.P99Latency.StdDev*1000, agg.P99Latency.CI95Low*1000, agg.P99Latency.CI95High*1000)
+		fmt.Printf("   Taxa de Erros:                      %.2f%% ± %.2f%%\n",
+			agg.ErrorRate.Mean*100, agg.ErrorRate.StdDev*100)
+	}
 
 	fmt.Println("\n  MÉTRICAS DE ORQUESTRAÇÃO")
 	fmt.Println(strings.Repeat("-", 80))
@@ -126,7 +500,126 @@ func main() {
 		fmt.Printf("   Tempo de Inicialização: %s\n", finalReportData.TimeInicialization)
 	}
 
-	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Println(" Benchmark concluído com sucesso!")
-	fmt.Println(strings.Repeat("=", 80) + "\n")
+	if st := finalReportData.Startup; st != nil {
+		fmt.Printf("\n INICIALIZAÇÃO DOS PODS NOVOS (%d pods, média em segundos)\n", len(st.Pods))
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Criação → Agendamento:              %.3f s\n", st.Scheduling.Mean)
+		fmt.Printf("   Agendamento → Imagem Disponível:    %.3f s\n", st.ImagePull.Mean)
+		fmt.Printf("   Imagem → Containers em Execução:    %.3f s\n", st.ContainerStart.Mean)
+		fmt.Printf("   Containers em Execução → Ready:     %.3f s\n", st.Readiness.Mean)
+		fmt.Printf("   Total (Criação → Ready):            %.3f s\n", st.Total.Mean)
+	}
+
+	if sm := finalReportData.Sampled; sm != nil && len(sm.Samples) > 0 {
+		fmt.Printf("\n LEITURAS CONTÍNUAS (%d amostras a cada %s: mín / média / p95 / máx)\n", len(sm.Samples), sm.Interval)
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   CPU (mCores):                       %.2f / %.2f / %.2f / %.2f\n", sm.CPU.Min, sm.CPU.Avg, sm.CPU.P95, sm.CPU.Max)
+		fmt.Printf("   Memória (MB):                       %.2f / %.2f / %.2f / %.2f\n",
+			sm.Memory.Min/(1024*1024), sm.Memory.Avg/(1024*1024), sm.Memory.P95/(1024*1024), sm.Memory.Max/(1024*1024))
+		fmt.Printf("   Réplicas:                           %.0f / %.2f / %.0f / %.0f\n", sm.Pods.Min, sm.Pods.Avg, sm.Pods.P95, sm.Pods.Max)
+	}
+
+	header := false
+	for _, e := range finalReportData.Executions {
+		snap := e.Snapshot
+		if snap == nil {
+			continue
+		}
+		if !header {
+			fmt.Println("\n SNAPSHOTS ANTES/DEPOIS DE CADA EXECUÇÃO")
+			fmt.Println(strings.Repeat("-", 80))
+			header = true
+		}
+		if snap.Error != "" {
+			fmt.Printf("   Execução %d: snapshot indisponível (%s)\n", e.Execution, snap.Error)
+			continue
+		}
+		fmt.Printf("   Execução %d: pods %d -> %d (%+d, %d novos), CPU %+.2f mCores, memória %+.2f MB\n",
+			e.Execution, snap.Before.Pods, snap.After.Pods, snap.PodDelta, len(snap.NewPods),
+			snap.CPUDelta, snap.MemoryDelta/(1024*1024))
+	}
+
+	header = false
+	for _, e := range finalReportData.Executions {
+		a := e.Autoscaling
+		if a == nil {
+			continue
+		}
+		if !header {
+			fmt.Println("\n AUTOSCALING POR EXECUÇÃO")
+			fmt.Println(strings.Repeat("-", 80))
+			header = true
+		}
+		if !a.ScaledUp {
+			fmt.Printf("   Execução %d: sem subida (%d réplicas)\n", e.Execution, a.Baseline)
+			continue
+		}
+		back := "retorno não observado"
+		if a.ReturnedToBaseline {
+			back = fmt.Sprintf("retorno em %s", a.TimeToBaseline.Round(time.Second))
+		}
+		fmt.Printf("   Execução %d: %d -> %d réplicas, 1ª subida em %s, pico em %s (%.3f réplicas/s), %s, zero: %t\n",
+			e.Execution, a.Baseline, a.PeakReplicas, a.TimeToFirstScaleUp.Round(time.Second), a.TimeToPeak.Round(time.Second),
+			a.ScaleUpRate, back, a.ScaledToZero)
+	}
+
+	header = false
+	for _, e := range finalReportData.Executions {
+		c := e.Cluster
+		if c == nil {
+			continue
+		}
+		if !header {
+			fmt.Println("\n MÉTRICAS DO CLUSTER POR EXECUÇÃO")
+			fmt.Println(strings.Repeat("-", 80))
+			header = true
+		}
+		if c.Error != "" {
+			fmt.Printf("   Execução %d: coleta indisponível (%s)\n", e.Execution, c.Error)
+			continue
+		}
+		fmt.Printf("   Execução %d (%s): pods escalados %d, CPU %.2f mCores, memória %.2f MB\n",
+			e.Execution, c.Window.Duration().Round(time.Millisecond), c.ScaledPodsDiff,
+			c.ClusterCPUUsage, c.ClusterMemUsage/(1024*1024))
+	}
+
+	if ef := finalReportData.Efficiency; ef != nil {
+		fmt.Println("\n EFICIÊNCIA DE RECURSOS")
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Requisições por Core-Segundo:       %.2f\n", ef.RequestsPerCoreSecond)
+		fmt.Printf("   MB-Segundos por Requisição:         %.4f\n", ef.MBSecondsPerRequest)
+		if ef.Resources != nil {
+			// Zero indica recurso não declarado na especificação dos pods
+			percent := func(u float64) string {
+				if u <= 0 {
+					return "N/A"
+				}
+				return fmt.Sprintf("%.1f%%", u*100)
+			}
+			fmt.Printf("   Utilização de CPU (request/limit):  %s / %s\n", percent(ef.CPURequestUtilization), percent(ef.CPULimitUtilization))
+			fmt.Printf("   Utilização de Memória (req/limit):  %s / %s\n", percent(ef.MemoryRequestUtilization), percent(ef.MemoryLimitUtilization))
+		} else {
+			fmt.Println("   Utilização (request/limit):         N/A (a fonte não leu a especificação dos pods)")
+		}
+	}
+
+	if c := finalReportData.Cost; c != nil {
+		currency := c.Pricing.Currency
+		fmt.Println("\n CUSTO ESTIMADO")
+		fmt.Println(strings.Repeat("-", 80))
+		fmt.Printf("   Custo Total:                        %.6f %s\n", c.Total.Total, currency)
+		fmt.Printf("   Custo por 1000 Requisições:         %.6f %s\n", c.Total.Per1000Requests, currency)
+		fmt.Printf("   Memória / CPU / Invocações / Nós:   %.6f / %.6f / %.6f / %.6f %s\n",
+			c.Total.Memory, c.Total.CPU, c.Total.Invocations, c.Total.Nodes, currency)
+		if c.Total.PostLoadDuration > 0 {
+			fmt.Printf("   Depois da Carga (incluído):         %.6f %s (%.2f réplica-s)\n", c.Total.PostLoad, currency, c.Total.PostLoadReplicaSeconds)
+		}
+		for _, e := range finalReportData.Executions {
+			if e.Cost == nil {
+				continue
+			}
+			fmt.Printf("   Execução %d: %.6f %s (%.6f %s por 1000 requisições, %.2f GB-s, %.2f vCPU-s)\n",
+				e.Execution, e.Cost.Total, currency, e.Cost.Per1000Requests, currency, e.Cost.GBSeconds, e.Cost.VCPUSeconds)
+		}
+	}
 }
@@ -0,0 +1,88 @@
+package metrics
+
+import "time"
+
+// AutoscalingTimeline descreve a reação do autoscaler a uma execução, a partir da
+// série de réplicas amostrada. Os tempos têm a resolução do intervalo de amostragem;
+// os de subida contam do início da execução e o de retorno conta do fim da carga.
+type AutoscalingTimeline struct {
+	Baseline     int // réplicas antes da execução
+	PeakReplicas int
+	Samples      int // leituras usadas, da execução até a próxima
+
+	ScaledUp           bool
+	TimeToFirstScaleUp time.Duration
+	TimeToPeak         time.Duration
+	ScaleUpRate        float64 // réplicas por segundo, da referência ao pico
+
+	ReturnedToBaseline bool
+	TimeToBaseline     time.Duration // do fim da execução até voltar à referência
+	ScaledToZero       bool
+}
+
+// NewAutoscalingTimeline analisa as leituras feitas entre o início da execução e
+// o início da próxima (ou o fim da série). Com baseline negativo a referência é a
+// última leitura até o início da execução. Retorna nil sem leituras no período.
+func NewAutoscalingTimeline(samples []MetricSample, baseline int, start, end, until time.Time) *AutoscalingTimeline {
+	var segment []MetricSample
+	var last *MetricSample // última leitura antes do início
+	for i, s := range samples {
+		if s.Timestamp.Before(start) {
+			last = &samples[i]
+			continue
+		}
+		if !until.IsZero() && !s.Timestamp.Before(until) {
+			break
+		}
+		segment = append(segment, s)
+	}
+	if len(segment) == 0 {
+		return nil
+	}
+
+	if baseline < 0 {
+		baseline = segment[0].Pods
+		if last != nil {
+			baseline = last.Pods
+		}
+	}
+
+	t := &AutoscalingTimeline{
+		Baseline:     baseline,
+		PeakReplicas: baseline,
+		Samples:      len(segment),
+	}
+
+	peakIndex := -1
+	for i, s := range segment {
+		if !t.ScaledUp && s.Pods > baseline {
+			t.ScaledUp = true
+			t.TimeToFirstScaleUp = s.Timestamp.Sub(start)
+		}
+		if s.Pods > t.PeakReplicas {
+			t.PeakReplicas = s.Pods
+			t.TimeToPeak = s.Timestamp.Sub(start)
+			peakIndex = i
+		}
+	}
+
+	if t.ScaledUp && t.TimeToPeak > 0 {
+		t.ScaleUpRate = float64(t.PeakReplicas-baseline) / t.TimeToPeak.Seconds()
+	}
+
+	// Retorno à referência e escala para zero depois do pico e do fim da carga
+	for _, s := range segment[peakIndex+1:] {
+		if s.Timestamp.Before(end) {
+			continue
+		}
+		if !t.ReturnedToBaseline && t.ScaledUp && s.Pods <= baseline {
+			t.ReturnedToBaseline = true
+			t.TimeToBaseline = s.Timestamp.Sub(end)
+		}
+		if s.Pods == 0 {
+			t.ScaledToZero = true
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+)
+
+// ColdStartSample é uma sondagem do modo coldstart: uma requisição enviada com a função sem pods
+type ColdStartSample struct {
+	Iteration    int
+	Timestamp    time.Time
+	ScaledToZero bool          // a função chegou a zero pods antes do envio
+	WaitedToZero time.Duration // tempo de espera até a função ficar sem pods
+	Latency      float64       // latência ponta a ponta, em segundos
+	StatusCode   int
+	PodsAfter    int // pods da função logo após a resposta
+	Error        string
+}
+
+// Cold indica se a sondagem mediu de fato um cold start bem-sucedido
+func (s ColdStartSample) Cold() bool {
+	return s.ScaledToZero && s.Error == "" && s.StatusCode < 400
+}
+
+// ColdStartMetrics resume a distribuição das latências de cold start de uma plataforma
+type ColdStartMetrics struct {
+	Platform    string
+	Function    string
+	Samples     []ColdStartSample
+	Latency     Stats
+	Percentiles []heyexec.LatencyPercentile
+	Failed      int // sondagens com erro ou sem escala para zero, fora da distribuição
+}
+
+// NewColdStartMetrics calcula a distribuição das latências das sondagens que
+// mediram um cold start; as demais são apenas contadas em Failed
+func NewColdStartMetrics(platform, function string, samples []ColdStartSample, percentiles []float64) *ColdStartMetrics {
+	m := &ColdStartMetrics{
+		Platform: platform,
+		Function: function,
+		Samples:  samples,
+	}
+
+	var latencies []float64
+	for _, s := range samples {
+		if !s.Cold() {
+			m.Failed++
+			continue
+		}
+		latencies = append(latencies, s.Latency)
+	}
+
+	m.Latency = NewStats(latencies)
+	if len(latencies) == 0 {
+		return m
+	}
+
+	sort.Float64s(latencies)
+	for _, pct := range percentiles {
+		m.Percentiles = append(m.Percentiles, heyexec.LatencyPercentile{
+			Percentage: pct / 100,
+			Latency:    heyexec.Percentile(latencies, pct),
+		})
+	}
+
+	return m
+}
@@ -0,0 +1,250 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+)
+
+// Separação mínima (D de Ashman) entre os dois modos para considerar a
+// distribuição de latências bimodal
+const minBimodalSeparation = 2.0
+
+// Número mínimo de medições para ajustar a mistura de dois modos
+const minBimodalSamples = 20
+
+// ColdWarmMetrics separa as requisições servidas a frio das servidas a quente.
+// Uma requisição é fria quando estava em andamento enquanto uma réplica nova
+// ficava pronta, ou quando a sua latência cai no modo lento de uma distribuição
+// bimodal.
+type ColdWarmMetrics struct {
+	Cold LatencyPopulation
+	Warm LatencyPopulation
+
+	ByReplica int // frias por coincidirem com a inicialização de uma réplica
+	ByLatency int // frias apenas pelo modo lento da distribuição
+
+	// Ajuste da mistura de dois modos, nil quando a distribuição não é bimodal
+	Bimodal *BimodalFit
+}
+
+// LatencyPopulation resume as latências de um grupo de requisições
+type LatencyPopulation struct {
+	Count      int
+	Percentage float64 // fração do total de requisições classificadas
+	heyexec.LatencyStats
+}
+
+// BimodalFit é o ajuste de uma mistura de duas normais sobre o logaritmo das latências
+type BimodalFit struct {
+	WarmLatency float64 // mediana geométrica do modo rápido, em segundos
+	ColdLatency float64 // mediana geométrica do modo lento, em segundos
+	ColdWeight  float64 // fração das requisições no modo lento
+	Separation  float64 // D de Ashman entre os dois modos
+	Threshold   float64 // menor latência classificada no modo lento, em segundos
+}
+
+// replicaWindow é o intervalo em que uma réplica nova ainda não estava pronta
+type replicaWindow struct {
+	start, end time.Time
+}
+
+// ClassifyRequests classifica as requisições bem-sucedidas das execuções como
+// frias ou quentes, usando a inicialização dos pods novos e o ajuste bimodal das
+// latências. Retorna nil quando não há medições individuais.
+func ClassifyRequests(runs []*heyexec.RunResult, pods []PodStartup, percentiles []float64) *ColdWarmMetrics {
+	var records []heyexec.RequestRecord
+	var starts []time.Time
+	for _, run := range runs {
+		if run == nil {
+			continue
+		}
+		for _, r := range run.Records {
+			if r.Error != "" {
+				continue
+			}
+			records = append(records, r)
+			starts = append(starts, run.StartTime.Add(time.Duration(r.Offset*float64(time.Second))))
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	windows := replicaWindows(pods)
+
+	latencies := make([]float64, len(records))
+	for i, r := range records {
+		latencies[i] = r.ResponseTime
+	}
+	fit, slow := fitBimodal(latencies)
+
+	m := &ColdWarmMetrics{Bimodal: fit}
+	var cold, warm []heyexec.RequestRecord
+	for i, r := range records {
+		end := starts[i].Add(time.Duration(r.ResponseTime * float64(time.Second)))
+		switch {
+		case duringStartup(windows, starts[i], end):
+			m.ByReplica++
+			cold = append(cold, r)
+		case slow != nil && slow[i]:
+			m.ByLatency++
+			cold = append(cold, r)
+		default:
+			warm = append(warm, r)
+		}
+	}
+
+	m.Cold = newLatencyPopulation(cold, len(records), percentiles)
+	m.Warm = newLatencyPopulation(warm, len(records), percentiles)
+	return m
+}
+
+// newLatencyPopulation resume um grupo de requisições em relação ao total
+func newLatencyPopulation(records []heyexec.RequestRecord, total int, percentiles []float64) LatencyPopulation {
+	return LatencyPopulation{
+		Count:        len(records),
+		Percentage:   float64(len(records)) / float64(total),
+		LatencyStats: heyexec.ComputeLatencyStats(records, percentiles),
+	}
+}
+
+// replicaWindows retorna, para cada pod novo, o intervalo entre a criação e o
+// pod ficar Ready (ou os containers entrarem em execução, sem a condição Ready)
+func replicaWindows(pods []PodStartup) []replicaWindow {
+	var windows []replicaWindow
+	for _, p := range pods {
+		end := p.Ready
+		if end.IsZero() {
+			end = p.Started
+		}
+		if p.Created.IsZero() || end.IsZero() {
+			continue
+		}
+		windows = append(windows, replicaWindow{start: p.Created, end: end})
+	}
+	return windows
+}
+
+// duringStartup indica se a requisição esteve em andamento enquanto alguma réplica nova ficava pronta
+func duringStartup(windows []replicaWindow, start, end time.Time) bool {
+	for _, w := range windows {
+		if !start.After(w.end) && !end.Before(w.start) {
+			return true
+		}
+	}
+	return false
+}
+
+// fitBimodal ajusta por EM uma mistura de duas normais sobre o logaritmo das
+// latências. Quando os modos são separados (D de Ashman acima do mínimo) e o modo
+// lento é a minoria, retorna o ajuste e quais medições pertencem ao modo lento.
+func fitBimodal(latencies []float64) (*BimodalFit, []bool) {
+	if len(latencies) < minBimodalSamples {
+		return nil, nil
+	}
+
+	x := make([]float64, len(latencies))
+	for i, l := range latencies {
+		x[i] = math.Log(math.Max(l, 1e-6))
+	}
+
+	// Inicialização pelos quartis da amostra ordenada
+	sorted := append([]float64(nil), x...)
+	sort.Float64s(sorted)
+	mu := [2]float64{sorted[len(sorted)/4], sorted[len(sorted)*9/10]}
+	if mu[1]-mu[0] < 1e-9 {
+		return nil, nil
+	}
+	spread := (sorted[len(sorted)-1] - sorted[0]) / 4
+	sigma := [2]float64{spread, spread}
+	weight := [2]float64{0.5, 0.5}
+
+	const minSigma = 1e-3
+	resp := make([]float64, len(x)) // responsabilidade do modo lento
+	prev := math.Inf(-1)
+	for iter := 0; iter < 200; iter++ {
+		// Passo E
+		logLikelihood := 0.0
+		for i, v := range x {
+			p0 := weight[0] * normalPDF(v, mu[0], sigma[0])
+			p1 := weight[1] * normalPDF(v, mu[1], sigma[1])
+			total := p0 + p1
+			if total == 0 {
+				resp[i] = 0
+				if v > (mu[0]+mu[1])/2 {
+					resp[i] = 1
+				}
+				continue
+			}
+			resp[i] = p1 / total
+			logLikelihood += math.Log(total)
+		}
+
+		// Passo M
+		var n [2]float64
+		var sum [2]float64
+		for i, v := range x {
+			n[0] += 1 - resp[i]
+			n[1] += resp[i]
+			sum[0] += (1 - resp[i]) * v
+			sum[1] += resp[i] * v
+		}
+		if n[0] < 1 || n[1] < 1 {
+			return nil, nil
+		}
+		for k := 0; k < 2; k++ {
+			mu[k] = sum[k] / n[k]
+			weight[k] = n[k] / float64(len(x))
+		}
+		var sq [2]float64
+		for i, v := range x {
+			sq[0] += (1 - resp[i]) * (v - mu[0]) * (v - mu[0])
+			sq[1] += resp[i] * (v - mu[1]) * (v - mu[1])
+		}
+		for k := 0; k < 2; k++ {
+			sigma[k] = math.Max(math.Sqrt(sq[k]/n[k]), minSigma)
+		}
+
+		if math.Abs(logLikelihood-prev) < 1e-6 {
+			break
+		}
+		prev = logLikelihood
+	}
+
+	if mu[1] < mu[0] {
+		return nil, nil
+	}
+
+	separation := math.Sqrt2 * (mu[1] - mu[0]) / math.Sqrt(sigma[0]*sigma[0]+sigma[1]*sigma[1])
+	if separation < minBimodalSeparation || weight[1] >= 0.5 {
+		return nil, nil
+	}
+
+	fit := &BimodalFit{
+		WarmLatency: math.Exp(mu[0]),
+		ColdLatency: math.Exp(mu[1]),
+		ColdWeight:  weight[1],
+		Separation:  separation,
+	}
+
+	slow := make([]bool, len(x))
+	for i, r := range resp {
+		if r > 0.5 && x[i] > mu[0] {
+			slow[i] = true
+			if fit.Threshold == 0 || latencies[i] < fit.Threshold {
+				fit.Threshold = latencies[i]
+			}
+		}
+	}
+
+	return fit, slow
+}
+
+// normalPDF é a densidade da normal com média mu e desvio sigma
+func normalPDF(x, mu, sigma float64) float64 {
+	z := (x - mu) / sigma
+	return math.Exp(-0.5*z*z) / (sigma * math.Sqrt(2*math.Pi))
+}
@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// Bytes em um GB, na convenção das plataformas que cobram por GB-segundo
+const bytesPerGB = 1024 * 1024 * 1024
+
+// CostEstimate é o custo estimado de uma execução, ou da soma das execuções, com a
+// tabela de preços configurada. Os recursos são integrados sobre a janela da carga
+// e sobre o tempo de vida das réplicas depois dela.
+type CostEstimate struct {
+	Duration time.Duration // janela da carga
+	Requests int
+	Samples  int // leituras do cluster usadas na integração
+
+	ReplicaSeconds float64
+	GBSeconds      float64
+	VCPUSeconds    float64
+	NodeHours      float64
+
+	// Custo de cada componente, na moeda da tabela de preços
+	Memory      float64
+	CPU         float64
+	Invocations float64
+	Nodes       float64
+	Total       float64
+
+	Per1000Requests float64 // zero sem requisições
+
+	// Parte das quantidades e do custo depois do fim da carga, enquanto as
+	// réplicas ainda existiam; já incluída nos valores acima
+	PostLoadDuration       time.Duration
+	PostLoadReplicaSeconds float64
+	PostLoad               float64
+}
+
+// CostMetrics reúne o custo estimado das execuções e a tabela de preços usada
+type CostMetrics struct {
+	Pricing parameters.CostParameters
+
+	// Soma de todas as execuções, inclusive as que falharam
+	Total CostEstimate
+
+	// Custo de cada execução bem-sucedida e custo por 1000 requisições entre elas
+	PerRun          Stats
+	Per1000Requests Stats
+}
+
+// NewCostEstimate estima o custo da carga entre start e end e do tempo de vida das
+// réplicas entre end e until, quando until é posterior a end. Os GB-segundos e
+// vCPU-segundos vêm dos recursos alocados a cada réplica, quando configurados, ou
+// do uso amostrado; os nós são cobrados pela duração total.
+func NewCostEstimate(pricing parameters.CostParameters, samples []MetricSample, start, end, until time.Time, requests int) CostEstimate {
+	e := windowCost(pricing, samples, start, end, requests)
+	if until.After(end) {
+		postLoad := windowCost(pricing, samples, end, until, 0)
+		e.add(postLoad)
+		e.Duration = end.Sub(start)
+		e.PostLoadDuration = postLoad.Duration
+		e.PostLoadReplicaSeconds = postLoad.ReplicaSeconds
+		e.PostLoad = postLoad.Total
+		e.total()
+	}
+	return e
+}
+
+// windowCost estima o custo da janela entre start e end
+func windowCost(pricing parameters.CostParameters, samples []MetricSample, start, end time.Time, requests int) CostEstimate {
+	e := CostEstimate{
+		Duration: end.Sub(start),
+		Requests: requests,
+	}
+
+	e.ReplicaSeconds, e.Samples = integrate(samples, start, end, func(s MetricSample) float64 { return float64(s.Pods) })
+
+	if pricing.ReplicaMemoryMB > 0 {
+		e.GBSeconds = e.ReplicaSeconds * pricing.ReplicaMemoryMB / 1024
+	} else {
+		byteSeconds, _ := integrate(samples, start, end, func(s MetricSample) float64 { return s.Memory })
+		e.GBSeconds = byteSeconds / bytesPerGB
+	}
+
+	if pricing.ReplicaVCPU > 0 {
+		e.VCPUSeconds = e.ReplicaSeconds * pricing.ReplicaVCPU
+	} else {
+		millicoreSeconds, _ := integrate(samples, start, end, func(s MetricSample) float64 { return s.CPU })
+		e.VCPUSeconds = millicoreSeconds / 1000
+	}
+
+	e.NodeHours = float64(pricing.Nodes) * e.Duration.Hours()
+
+	e.Memory = e.GBSeconds * pricing.PerGBSecond
+	e.CPU = e.VCPUSeconds * pricing.PerVCPUSecond
+	e.Invocations = float64(requests) / 1e6 * pricing.PerMillionInvocations
+	e.Nodes = e.NodeHours * pricing.PerNodeHour
+	e.total()
+
+	return e
+}
+
+// NewCostMetrics soma o custo estimado de cada execução. Retorna nil quando
+// nenhuma execução tem estimativa.
+func NewCostMetrics(pricing parameters.CostParameters, executions []ExecutionMetrics) *CostMetrics {
+	m := &CostMetrics{Pricing: pricing}
+
+	var perRun, per1000 []float64
+	found := false
+	for _, execution := range executions {
+		e := execution.Cost
+		if e == nil {
+			continue
+		}
+		found = true
+		m.Total.add(*e)
+
+		if execution.Error != "" {
+			continue
+		}
+		perRun = append(perRun, e.Total)
+		if e.Requests > 0 {
+			per1000 = append(per1000, e.Per1000Requests)
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	m.Total.total()
+	m.PerRun = NewStats(perRun)
+	m.Per1000Requests = NewStats(per1000)
+	return m
+}
+
+// add acumula as quantidades e os custos de outra estimativa
+func (e *CostEstimate) add(other CostEstimate) {
+	e.Duration += other.Duration
+	e.Requests += other.Requests
+	e.Samples += other.Samples
+	e.ReplicaSeconds += other.ReplicaSeconds
+	e.GBSeconds += other.GBSeconds
+	e.VCPUSeconds += other.VCPUSeconds
+	e.NodeHours += other.NodeHours
+	e.Memory += other.Memory
+	e.CPU += other.CPU
+	e.Invocations += other.Invocations
+	e.Nodes += other.Nodes
+	e.PostLoadDuration += other.PostLoadDuration
+	e.PostLoadReplicaSeconds += other.PostLoadReplicaSeconds
+	e.PostLoad += other.PostLoad
+}
+
+// total soma os componentes e calcula o custo por 1000 requisições
+func (e *CostEstimate) total() {
+	e.Total = e.Memory + e.CPU + e.Invocations + e.Nodes
+	e.Per1000Requests = 0
+	if e.Requests > 0 {
+		e.Per1000Requests = e.Total / float64(e.Requests) * 1000
+	}
+}
+
+// integrate soma valor × segundos sobre a janela, mantendo cada leitura até a
+// seguinte (a última até o fim da janela). O trecho antes da primeira leitura não
+// é contado. Retorna também o número de leituras que cobriram parte da janela.
+func integrate(samples []MetricSample, start, end time.Time, value func(MetricSample) float64) (float64, int) {
+	total := 0.0
+	used := 0
+	for i, s := range samples {
+		from := s.Timestamp
+		if from.Before(start) {
+			from = start
+		}
+		to := end
+		if i+1 < len(samples) && samples[i+1].Timestamp.Before(end) {
+			to = samples[i+1].Timestamp
+		}
+		if !to.After(from) {
+			continue
+		}
+		total += value(s) * to.Sub(from).Seconds()
+		used++
+	}
+	return total, used
+}
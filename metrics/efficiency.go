@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// ResourceReader é implementado pelas fontes de métricas que leem a especificação
+// dos pods da função; sem ele a utilização relativa aos requests e limits fica
+// desconhecida
+type ResourceReader interface {
+	PodResources(ctx context.Context, function string) (PodResources, error)
+}
+
+// PodResources são os recursos declarados para uma réplica da função, somados
+// entre os containers e em média entre as réplicas. Zero indica que o recurso não
+// foi declarado em todos os containers.
+type PodResources struct {
+	Pods          int     // réplicas cujas especificações foram lidas
+	CPURequest    float64 // millicores
+	CPULimit      float64 // millicores
+	MemoryRequest float64 // bytes
+	MemoryLimit   float64 // bytes
+}
+
+// EfficiencyMetrics normaliza o consumo de recursos da função pela carga atendida,
+// a partir do uso amostrado integrado sobre a janela da carga
+type EfficiencyMetrics struct {
+	Requests int
+	Samples  int // leituras do cluster usadas na integração
+
+	CPUCoreSeconds  float64
+	MemoryMBSeconds float64
+	ReplicaSeconds  float64
+
+	RequestsPerCoreSecond float64
+	MBSecondsPerRequest   float64
+
+	// Especificação das réplicas e uso médio por réplica em relação a ela (0-1),
+	// zero quando a fonte não lê as especificações ou o recurso não é declarado
+	Resources                *PodResources
+	CPURequestUtilization    float64
+	CPULimitUtilization      float64
+	MemoryRequestUtilization float64
+	MemoryLimitUtilization   float64
+}
+
+// NewEfficiencyMetrics calcula a eficiência da janela entre start e end. Retorna
+// nil quando nenhuma leitura cobre a janela.
+func NewEfficiencyMetrics(samples []MetricSample, start, end time.Time, requests int, resources *PodResources) *EfficiencyMetrics {
+	millicoreSeconds, used := integrate(samples, start, end, func(s MetricSample) float64 { return s.CPU })
+	if used == 0 {
+		return nil
+	}
+	byteSeconds, _ := integrate(samples, start, end, func(s MetricSample) float64 { return s.Memory })
+	replicaSeconds, _ := integrate(samples, start, end, func(s MetricSample) float64 { return float64(s.Pods) })
+
+	e := &EfficiencyMetrics{
+		Requests:        requests,
+		Samples:         used,
+		CPUCoreSeconds:  millicoreSeconds / 1000,
+		MemoryMBSeconds: byteSeconds / (1024 * 1024),
+		ReplicaSeconds:  replicaSeconds,
+		Resources:       resources,
+	}
+	e.ratios()
+
+	if resources != nil {
+		e.CPURequestUtilization = utilization(millicoreSeconds, replicaSeconds, resources.CPURequest)
+		e.CPULimitUtilization = utilization(millicoreSeconds, replicaSeconds, resources.CPULimit)
+		e.MemoryRequestUtilization = utilization(byteSeconds, replicaSeconds, resources.MemoryRequest)
+		e.MemoryLimitUtilization = utilization(byteSeconds, replicaSeconds, resources.MemoryLimit)
+	}
+
+	return e
+}
+
+// NewEfficiencySummary combina a eficiência das execuções bem-sucedidas. As
+// utilizações são a média de cada execução ponderada pelos réplica-segundos.
+// Retorna nil quando nenhuma execução tem eficiência calculada.
+func NewEfficiencySummary(executions []ExecutionMetrics) *EfficiencyMetrics {
+	var s *EfficiencyMetrics
+	var cpuRequest, cpuLimit, memoryRequest, memoryLimit weightedMean
+	for _, execution := range executions {
+		e := execution.Efficiency
+		if e == nil || execution.Error != "" {
+			continue
+		}
+		if s == nil {
+			s = &EfficiencyMetrics{}
+		}
+
+		s.Requests += e.Requests
+		s.Samples += e.Samples
+		s.CPUCoreSeconds += e.CPUCoreSeconds
+		s.MemoryMBSeconds += e.MemoryMBSeconds
+		s.ReplicaSeconds += e.ReplicaSeconds
+		if e.Resources != nil {
+			s.Resources = e.Resources
+		}
+
+		cpuRequest.add(e.CPURequestUtilization, e.ReplicaSeconds)
+		cpuLimit.add(e.CPULimitUtilization, e.ReplicaSeconds)
+		memoryRequest.add(e.MemoryRequestUtilization, e.ReplicaSeconds)
+		memoryLimit.add(e.MemoryLimitUtilization, e.ReplicaSeconds)
+	}
+	if s == nil {
+		return nil
+	}
+
+	s.ratios()
+	s.CPURequestUtilization = cpuRequest.mean()
+	s.CPULimitUtilization = cpuLimit.mean()
+	s.MemoryRequestUtilization = memoryRequest.mean()
+	s.MemoryLimitUtilization = memoryLimit.mean()
+
+	return s
+}
+
+// weightedMean acumula uma média ponderada, ignorando os valores desconhecidos (zero)
+type weightedMean struct {
+	sum, weight float64
+}
+
+func (w *weightedMean) add(value, weight float64) {
+	if value > 0 && weight > 0 {
+		w.sum += value * weight
+		w.weight += weight
+	}
+}
+
+func (w weightedMean) mean() float64 {
+	if w.weight == 0 {
+		return 0
+	}
+	return w.sum / w.weight
+}
+
+// ratios calcula as requisições por core-segundo e os MB-segundos por requisição
+func (e *EfficiencyMetrics) ratios() {
+	e.RequestsPerCoreSecond = 0
+	if e.CPUCoreSeconds > 0 {
+		e.RequestsPerCoreSecond = float64(e.Requests) / e.CPUCoreSeconds
+	}
+	e.MBSecondsPerRequest = 0
+	if e.Requests > 0 {
+		e.MBSecondsPerRequest = e.MemoryMBSeconds / float64(e.Requests)
+	}
+}
+
+// utilization divide o uso integrado pela capacidade declarada das réplicas no
+// mesmo período; zero quando o recurso não é declarado ou não houve réplicas
+func utilization(usageSeconds, replicaSeconds, perReplica float64) float64 {
+	if perReplica <= 0 || replicaSeconds <= 0 {
+		return 0
+	}
+	return usageSeconds / (replicaSeconds * perReplica)
+}
@@ -54,6 +54,13 @@ POD_SCALED_DIFF_GAUGE = Gauge(
     ['platform', 'function', 'namespace']
 )
 
+# Início da última coleta concluída; o benchmark espera uma coleta iniciada
+# depois do fim de cada execução antes do snapshot final
+LAST_COLLECTION_GAUGE = Gauge(
+    'serverless_exporter_last_collection_timestamp_seconds',
+    'Instante Unix do início da última coleta concluída'
+)
+
 # --- Identificadores por plataforma (Labels para identificar funções) ---
 PLATFORM_LABELS = {
     'knative': 'serving.knative.dev/service',
@@ -66,6 +73,14 @@ PLATFORM_LABELS = {
 initial_pod_counts = {}
 benchmark_start_time = None
 
+# Funções já observadas: {(platform, function_name): namespace}
+# Usado para publicar contagem zero quando todas as réplicas de uma função somem
+known_functions = {}
+
+# Séries publicadas por pod: {(namespace, pod_name, function_name)}
+# Usado para remover as séries de pods que deixaram de existir
+published_pods = set()
+
 # --- Funções de Coleta de Métricas ---
 def get_metrics_from_metrics_server(namespace, pod_name):
     """Obtém métricas de CPU e memória de um pod do Metrics Server."""
@@ -125,9 +140,12 @@ def get_function_name_from_pod(pod_labels, platform_config):
 
 def collect_metrics_loop():
     """Coleta métricas de pods, CPU, memória e tempo de inicialização."""
-    global initial_pod_counts
+    global initial_pod_counts, published_pods
 
+    collection_started = time.time()
     current_pod_counts = {platform: {} for platform in PLATFORM_LABELS} # {platform: {function_name: count}}
+    function_namespaces = {} # {(platform, function_name): namespace}
+    seen_pods = set()
     all_pods = []
 
     try:
@@ -150,6 +168,8 @@ def collect_metrics_loop():
         if function_name not in current_pod_counts[platform]:
             current_pod_counts[platform][function_name] = 0
         current_pod_counts[platform][function_name] += 1
+        function_namespaces[(platform, function_name)] = ns
+        seen_pods.add((ns, name, function_name))
 
         # Coleta CPU e Memória do Metrics Server
         cpu_usage, memory_usage = get_metrics_from_metrics_server(ns, name)
@@ -179,9 +199,26 @@ def collect_metrics_loop():
             # print(f"Erro ao calcular tempo de inicialização para pod {name}: {e}")
             pass # Ignora erro e não seta a métrica
 
+    # Remove as séries de pods que não existem mais, para não somar valores antigos
+    for ns, name, function_name in published_pods - seen_pods:
+        for gauge in (CPU_GAUGE, MEM_GAUGE, BOOT_GAUGE):
+            try:
+                gauge.remove(ns, name, function_name)
+            except KeyError:
+                pass
+    published_pods = seen_pods
+
+    # Funções observadas antes e sem pods agora escalaram para zero
+    for (platform, function_name), known_ns in known_functions.items():
+        if function_name not in current_pod_counts[platform]:
+            current_pod_counts[platform][function_name] = 0
+            function_namespaces[(platform, function_name)] = known_ns
+    known_functions.update(function_namespaces)
+
     # Atualiza métricas de contagem de pods por plataforma e função
     for platform, functions in current_pod_counts.items():
         for function_name, count in functions.items():
+            ns = function_namespaces[(platform, function_name)]
             POD_COUNT_GAUGE.labels(platform=platform, function=function_name, namespace=ns).set(count)
 
             # Calcula diferença de pods se o benchmark já começou
@@ -189,6 +226,8 @@ def collect_metrics_loop():
                 diff = count - initial_pod_counts[platform][function_name]
                 POD_SCALED_DIFF_GAUGE.labels(platform=platform, function=function_name, namespace=ns).set(diff)
 
+    LAST_COLLECTION_GAUGE.set(collection_started)
+
 
 def set_initial_pod_counts():
     global initial_pod_counts
@@ -221,7 +260,7 @@ def main():
 
     while True:
         collect_metrics_loop()
-        time.sleep(os.getenv('COLLECTION_INTERVAL_SECONDS', 10))
+        time.sleep(float(os.getenv('COLLECTION_INTERVAL_SECONDS', 10)))
 
 if __name__ == '__main__':
     main()
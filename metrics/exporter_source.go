@@ -0,0 +1,276 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// Métrica do exporter com o início da última coleta concluída, em segundos Unix
+const exporterCollectionMetric = "serverless_exporter_last_collection_timestamp_seconds"
+
+// ExporterSource lê as métricas do exporter Prometheus em metrics/exporter.py,
+// selecionando as séries pelos labels e pelo mapeamento configurados
+type ExporterSource struct {
+	exporterURL        string
+	httpClient         *http.Client
+	selector           Selector
+	mapping            parameters.MetricMapping
+	collectionInterval time.Duration
+}
+
+// NewExporterSource cria uma nova instância do ExporterSource
+func NewExporterSource(exporterURL string) *ExporterSource {
+	return &ExporterSource{
+		exporterURL: exporterURL,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		mapping:     parameters.DefaultParameters().Metrics.Mapping,
+	}
+}
+
+// SetSelector define os labels (function, namespace, platform) que as séries do exporter devem ter
+func (s *ExporterSource) SetSelector(selector Selector) {
+	s.selector = selector
+}
+
+// SetMapping define as métricas do exporter usadas em cada campo do resultado
+func (s *ExporterSource) SetMapping(mapping parameters.MetricMapping) {
+	s.mapping = mapping
+}
+
+// SetCollectionInterval define o intervalo entre as coletas do exporter
+// (COLLECTION_INTERVAL_SECONDS), que limita a espera de WaitForCollection
+func (s *ExporterSource) SetCollectionInterval(interval time.Duration) {
+	s.collectionInterval = interval
+}
+
+// WaitForCollection espera o exporter publicar uma coleta iniciada depois de
+// after, por até dois intervalos de coleta e mais 10s. Retorna ErrNotSupported
+// quando o exporter não publica o instante das coletas.
+func (s *ExporterSource) WaitForCollection(ctx context.Context, after time.Time) error {
+	interval := s.collectionInterval
+	if interval <= 0 {
+		interval = 10 * time.Second // padrão do exporter
+	}
+	timeout := 2*interval + 10*time.Second
+	deadline := time.Now().Add(timeout)
+	poll := min(interval/5, 500*time.Millisecond)
+
+	for {
+		families, err := s.scrape(ctx)
+		if err == nil {
+			samples := SelectSeries(families, exporterCollectionMetric, nil)
+			if len(samples) == 0 {
+				return fmt.Errorf("exporter does not publish %s: %w", exporterCollectionMetric, ErrNotSupported)
+			}
+			if aggregateSamples(samples, parameters.AggregationMax) >= float64(after.UnixNano())/1e9 {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("no exporter collection after %s: %w", after.Format(time.RFC3339), err)
+			}
+			return fmt.Errorf("no exporter collection after %s within %s", after.Format(time.RFC3339), timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(poll):
+		}
+	}
+}
+
+// Collect coleta as métricas do endpoint Prometheus do exporter. O exporter publica
+// apenas os valores atuais, então a janela da carga não é usada.
+func (s *ExporterSource) Collect(ctx context.Context, window Window) (ConsolidatedMetrics, error) {
+	families, err := s.scrape(ctx)
+	if err != nil {
+		return ConsolidatedMetrics{}, err
+	}
+
+	return s.parsePrometheusMetrics(families), nil
+}
+
+// PodCount retorna o número atual de pods da função, segundo a métrica associada a
+// pod_count. Apenas a série publicada com valor zero conta como zero pods; sem série
+// a função é desconhecida do exporter e o retorno é ErrNotFound.
+func (s *ExporterSource) PodCount(ctx context.Context, function string) (int, error) {
+	families, err := s.scrape(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	samples := s.selectBinding(families, s.mapping.PodCount, function)
+	if len(samples) == 0 {
+		return 0, fmt.Errorf("pod count of function %q: %w", function, ErrNotFound)
+	}
+	return int(aggregateSamples(samples, s.mapping.PodCount.Aggregation)), nil
+}
+
+// Sample lê o uso de CPU, memória e o número de pods da função publicados pelo
+// exporter. Com function vazio usa a função do seletor.
+func (s *ExporterSource) Sample(ctx context.Context, function string) (MetricSample, error) {
+	families, err := s.scrape(ctx)
+	if err != nil {
+		return MetricSample{}, err
+	}
+
+	return MetricSample{
+		Timestamp: time.Now(),
+		CPU:       s.aggregate(families, s.mapping.CPUUsage, function),
+		Memory:    s.aggregate(families, s.mapping.MemoryUsage, function),
+		Pods:      int(s.aggregate(families, s.mapping.PodCount, function)),
+	}, nil
+}
+
+// PodNames retorna os pods da função que aparecem no label pod das métricas de
+// CPU, memória e inicialização. O exporter só publica essas séries para pods com
+// leituras, então pods recém-criados podem ainda não aparecer.
+func (s *ExporterSource) PodNames(ctx context.Context, function string) ([]string, error) {
+	families, err := s.scrape(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, binding := range []parameters.MetricBinding{s.mapping.CPUUsage, s.mapping.MemoryUsage, s.mapping.BootDuration} {
+		for _, sample := range s.selectBinding(families, binding, function) {
+			if pod := sample.Labels["pod"]; pod != "" && !seen[pod] {
+				seen[pod] = true
+				names = append(names, pod)
+			}
+		}
+	}
+	return names, nil
+}
+
+// CheckMapping consulta o exporter e retorna um aviso para cada métrica associada
+// que não aparece na coleta ou que não tem séries com os labels esperados
+func (s *ExporterSource) CheckMapping(ctx context.Context) ([]string, error) {
+	families, err := s.scrape(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(s.mapping.Bindings()))
+	for field := range s.mapping.Bindings() {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var warnings []string
+	for _, field := range fields {
+		binding := s.mapping.Bindings()[field]
+		switch {
+		case !binding.Enabled():
+			continue
+		case !hasSeries(families, binding.Metric):
+			warnings = append(warnings, fmt.Sprintf("metric %s (mapping %s) is not exposed by the exporter", binding.Metric, field))
+		case len(s.selectBinding(families, binding, "")) == 0 && field == "pod_count":
+			warnings = append(warnings, fmt.Sprintf("metric %s has no series for function %q; it counts as zero pods until the exporter sees a pod of the function (check the function name)", binding.Metric, s.selector["function"]))
+		case len(s.selectBinding(families, binding, "")) == 0:
+			warnings = append(warnings, fmt.Sprintf("metric %s (mapping %s) has no series matching the selected labels", binding.Metric, field))
+		}
+	}
+
+	return warnings, nil
+}
+
+// selectorFor retorna o seletor configurado, trocando a função quando informada
+func (s *ExporterSource) selectorFor(function string) Selector {
+	selector := Selector{}
+	for k, v := range s.selector {
+		selector[k] = v
+	}
+	if function != "" {
+		selector["function"] = function
+	}
+	return selector
+}
+
+// selectBinding retorna as séries da métrica associada que atendem ao seletor e aos filtros da associação
+func (s *ExporterSource) selectBinding(families []*MetricFamily, binding parameters.MetricBinding, function string) []PromSample {
+	if !binding.Enabled() {
+		return nil
+	}
+
+	selector := s.selectorFor(function)
+	for k, v := range binding.Labels {
+		selector[k] = v
+	}
+	return SelectSeries(families, binding.Metric, selector)
+}
+
+// aggregate aplica a agregação da associação às séries selecionadas
+func (s *ExporterSource) aggregate(families []*MetricFamily, binding parameters.MetricBinding, function string) float64 {
+	return aggregateSamples(s.selectBinding(families, binding, function), binding.Aggregation)
+}
+
+// scrape lê e interpreta o conteúdo do endpoint Prometheus do exporter
+func (s *ExporterSource) scrape(ctx context.Context) ([]*MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.exporterURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach exporter at %s: %w", s.exporterURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exporter returned non-200 status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exporter response body: %w", err)
+	}
+
+	openMetrics := strings.HasPrefix(resp.Header.Get("Content-Type"), "application/openmetrics-text")
+	families, err := ParseExposition(string(body), openMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse exporter metrics: %w", err)
+	}
+
+	return families, nil
+}
+
+// parsePrometheusMetrics preenche as métricas de orquestração a partir das
+// métricas associadas no mapeamento, agregando as séries selecionadas
+func (s *ExporterSource) parsePrometheusMetrics(families []*MetricFamily) ConsolidatedMetrics {
+	metrics := ConsolidatedMetrics{
+		PodStartedAt: make(map[string]float64),
+	}
+
+	metrics.ClusterCPUUsage = s.aggregate(families, s.mapping.CPUUsage, "")
+	metrics.ClusterMemUsage = s.aggregate(families, s.mapping.MemoryUsage, "")
+	metrics.ScaledPodsDiff = int(s.aggregate(families, s.mapping.ScaledPods, ""))
+
+	boot := s.aggregate(families, s.mapping.BootDuration, "")
+	metrics.TimeInicialization = time.Duration(boot * float64(time.Second))
+
+	// Várias séries do mesmo pod são combinadas pela agregação da associação
+	byPod := make(map[string][]PromSample)
+	for _, sample := range s.selectBinding(families, s.mapping.StartedAt, "") {
+		if pod := sample.Labels["pod"]; pod != "" {
+			byPod[pod] = append(byPod[pod], sample)
+		}
+	}
+	for pod, samples := range byPod {
+		metrics.PodStartedAt[pod] = aggregateSamples(samples, s.mapping.StartedAt.Aggregation)
+	}
+
+	return metrics
+}
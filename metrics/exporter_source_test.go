@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// exporterStub publica o instante da última coleta, que avança para next depois
+// de um número de raspagens
+type exporterStub struct {
+	mu       sync.Mutex
+	scrapes  int
+	collect  float64
+	next     float64
+	advance  int  // raspagens antes de a coleta avançar
+	noMetric bool // exporter antigo, sem o instante das coletas
+}
+
+func (e *exporterStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.scrapes++
+	if e.scrapes > e.advance {
+		e.collect = e.next
+	}
+	if e.noMetric {
+		fmt.Fprintln(w, `serverless_pod_count{function="hello"} 1`)
+		return
+	}
+	fmt.Fprintf(w, "%s %g\n", exporterCollectionMetric, e.collect)
+}
+
+func TestExporterWaitForCollection(t *testing.T) {
+	end := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		stub    *exporterStub
+		wantErr bool
+		notSupp bool
+	}{
+		{name: "already fresh", stub: &exporterStub{collect: 1700000001, next: 1700000001}},
+		{name: "fresh after scrapes", stub: &exporterStub{collect: 1699999995, next: 1700000002, advance: 2}},
+		{name: "never fresh", stub: &exporterStub{collect: 1699999995, next: 1699999995}, wantErr: true},
+		{name: "old exporter", stub: &exporterStub{noMetric: true}, wantErr: true, notSupp: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.stub)
+			defer server.Close()
+
+			source := NewExporterSource(server.URL)
+			source.SetCollectionInterval(50 * time.Millisecond)
+
+			// O prazo do contexto encerra a espera antes do limite de 2 intervalos + 10s
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			err := source.WaitForCollection(ctx, end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WaitForCollection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNotSupported) != tt.notSupp {
+				t.Errorf("WaitForCollection() error = %v, want ErrNotSupported %v", err, tt.notSupp)
+			}
+		})
+	}
+}
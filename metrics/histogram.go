@@ -0,0 +1,361 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// Configuração padrão do histograma de latência: resolução de 1 µs,
+// limite de 1 hora e 3 dígitos significativos (erro relativo de até 0,1%)
+const (
+	DefaultHistogramHighestTrackable    = int64(time.Hour / time.Microsecond)
+	DefaultHistogramSignificantFigures  = 3
+	histogramLowestTrackable            = 1
+	histogramMaxSignificantFigures      = 5
+	histogramDefaultDisplayBucketsCount = 10
+)
+
+// LatencyHistogram é um histograma de alta faixa dinâmica (HDR) para latências,
+// registradas em microssegundos. Histogramas com a mesma configuração podem ser
+// combinados com Merge sem perda de precisão, o que permite calcular percentis
+// reais sobre várias execuções ou vários processos geradores de carga.
+type LatencyHistogram struct {
+	highestTrackable   int64
+	significantFigures int
+
+	subBucketHalfCountMagnitude int
+	subBucketHalfCount          int
+	subBucketCount              int
+	subBucketMask               int64
+
+	counts     []int64
+	totalCount int64
+	min        int64
+	max        int64
+	sum        float64
+}
+
+// HistogramDisplayBucket representa uma faixa do histograma para exibição
+type HistogramDisplayBucket struct {
+	From  float64 // em segundos
+	To    float64 // em segundos
+	Count int64
+}
+
+// NewLatencyHistogram cria um histograma vazio que registra latências de até
+// highestTrackable microssegundos com a precisão informada
+func NewLatencyHistogram(highestTrackable int64, significantFigures int) (*LatencyHistogram, error) {
+	if significantFigures < 1 || significantFigures > histogramMaxSignificantFigures {
+		return nil, fmt.Errorf("significant figures must be between 1 and %d", histogramMaxSignificantFigures)
+	}
+	if highestTrackable < 2*histogramLowestTrackable {
+		return nil, fmt.Errorf("highest trackable value must be at least %d", 2*histogramLowestTrackable)
+	}
+
+	largestValueWithSingleUnitResolution := 2 * int64(math.Pow10(significantFigures))
+	subBucketCountMagnitude := int(math.Ceil(math.Log2(float64(largestValueWithSingleUnitResolution))))
+	subBucketHalfCountMagnitude := subBucketCountMagnitude - 1
+	if subBucketHalfCountMagnitude < 0 {
+		subBucketHalfCountMagnitude = 0
+	}
+
+	subBucketCount := 1 << (subBucketHalfCountMagnitude + 1)
+
+	// Cada bucket cobre o dobro da faixa do anterior
+	smallestUntrackable := int64(subBucketCount)
+	bucketCount := 1
+	for smallestUntrackable < highestTrackable {
+		if smallestUntrackable > math.MaxInt64/2 {
+			bucketCount++
+			break
+		}
+		smallestUntrackable <<= 1
+		bucketCount++
+	}
+
+	h := &LatencyHistogram{
+		highestTrackable:            highestTrackable,
+		significantFigures:          significantFigures,
+		subBucketHalfCountMagnitude: subBucketHalfCountMagnitude,
+		subBucketHalfCount:          subBucketCount / 2,
+		subBucketCount:              subBucketCount,
+		subBucketMask:               int64(subBucketCount - 1),
+		min:                         math.MaxInt64,
+	}
+	h.counts = make([]int64, (bucketCount+1)*h.subBucketHalfCount)
+
+	return h, nil
+}
+
+// NewDefaultLatencyHistogram cria um histograma com a configuração padrão
+func NewDefaultLatencyHistogram() *LatencyHistogram {
+	h, _ := NewLatencyHistogram(DefaultHistogramHighestTrackable, DefaultHistogramSignificantFigures)
+	return h
+}
+
+// RecordSeconds registra uma latência expressa em segundos
+func (h *LatencyHistogram) RecordSeconds(seconds float64) error {
+	return h.RecordValues(secondsToMicros(seconds), 1)
+}
+
+// RecordValues registra count ocorrências de uma latência em microssegundos
+func (h *LatencyHistogram) RecordValues(micros int64, count int64) error {
+	if micros < 0 {
+		return fmt.Errorf("latency cannot be negative: %d", micros)
+	}
+	if micros > h.highestTrackable {
+		return fmt.Errorf("latency %d µs exceeds the highest trackable value %d µs", micros, h.highestTrackable)
+	}
+	if count <= 0 {
+		return nil
+	}
+
+	h.counts[h.countsIndexFor(micros)] += count
+	h.totalCount += count
+	h.sum += float64(micros) * float64(count)
+	if micros < h.min {
+		h.min = micros
+	}
+	if micros > h.max {
+		h.max = micros
+	}
+
+	return nil
+}
+
+// Merge soma as contagens de outro histograma com a mesma configuração
+func (h *LatencyHistogram) Merge(other *LatencyHistogram) error {
+	if other == nil || other.totalCount == 0 {
+		return nil
+	}
+	if other.highestTrackable != h.highestTrackable || other.significantFigures != h.significantFigures {
+		return fmt.Errorf("cannot merge histograms with different configurations")
+	}
+
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+	h.totalCount += other.totalCount
+	h.sum += other.sum
+	if other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+
+	return nil
+}
+
+// TotalCount retorna o número de latências registradas
+func (h *LatencyHistogram) TotalCount() int64 {
+	return h.totalCount
+}
+
+// Mean retorna a latência média em segundos
+func (h *LatencyHistogram) Mean() float64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return h.sum / float64(h.totalCount) / 1e6
+}
+
+// Min retorna a menor latência registrada em segundos
+func (h *LatencyHistogram) Min() float64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return float64(h.min) / 1e6
+}
+
+// Max retorna a maior latência registrada em segundos
+func (h *LatencyHistogram) Max() float64 {
+	return float64(h.max) / 1e6
+}
+
+// ValueAtPercentile retorna a latência em segundos no percentil p (0-100)
+func (h *LatencyHistogram) ValueAtPercentile(p float64) float64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+
+	p = math.Min(math.Max(p, 0), 100)
+	target := int64(math.Ceil(p / 100 * float64(h.totalCount)))
+	if target < 1 {
+		target = 1
+	}
+
+	var cumulative int64
+	for i, count := range h.counts {
+		cumulative += count
+		if cumulative >= target {
+			value := h.highestEquivalentValue(h.valueFromCountsIndex(i))
+			// O maior valor equivalente nunca ultrapassa o máximo observado
+			if value > h.max {
+				value = h.max
+			}
+			return float64(value) / 1e6
+		}
+	}
+
+	return float64(h.max) / 1e6
+}
+
+// DisplayBuckets distribui as contagens em n faixas de largura igual entre a
+// menor e a maior latência, no mesmo formato do histograma do hey
+func (h *LatencyHistogram) DisplayBuckets(n int) []HistogramDisplayBucket {
+	if h.totalCount == 0 {
+		return nil
+	}
+	if n <= 0 {
+		n = histogramDefaultDisplayBucketsCount
+	}
+
+	low := float64(h.min)
+	high := float64(h.max)
+	width := (high - low) / float64(n)
+
+	buckets := make([]HistogramDisplayBucket, n)
+	for i := range buckets {
+		buckets[i].From = (low + width*float64(i)) / 1e6
+		buckets[i].To = (low + width*float64(i+1)) / 1e6
+	}
+
+	for i, count := range h.counts {
+		if count == 0 {
+			continue
+		}
+
+		// Usa o ponto médio da faixa equivalente, limitado aos extremos observados
+		value := float64(h.medianEquivalentValue(h.valueFromCountsIndex(i)))
+		value = math.Min(math.Max(value, low), high)
+
+		bi := n - 1
+		if width > 0 {
+			bi = int((value - low) / width)
+			if bi >= n {
+				bi = n - 1
+			}
+		}
+		buckets[bi].Count += count
+	}
+
+	return buckets
+}
+
+// histogramJSON é a representação serializada do histograma, com contagens esparsas
+type histogramJSON struct {
+	HighestTrackable   int64            `json:"highest_trackable_us"`
+	SignificantFigures int              `json:"significant_figures"`
+	TotalCount         int64            `json:"total_count"`
+	Min                int64            `json:"min_us"`
+	Max                int64            `json:"max_us"`
+	Sum                float64          `json:"sum_us"`
+	Counts             map[string]int64 `json:"counts"`
+}
+
+// MarshalJSON serializa o histograma para que processos geradores de carga
+// distintos possam enviá-lo e combiná-lo posteriormente
+func (h *LatencyHistogram) MarshalJSON() ([]byte, error) {
+	out := histogramJSON{
+		HighestTrackable:   h.highestTrackable,
+		SignificantFigures: h.significantFigures,
+		TotalCount:         h.totalCount,
+		Min:                h.min,
+		Max:                h.max,
+		Sum:                h.sum,
+		Counts:             make(map[string]int64),
+	}
+	for i, count := range h.counts {
+		if count != 0 {
+			out.Counts[strconv.Itoa(i)] = count
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON reconstrói um histograma serializado por MarshalJSON
+func (h *LatencyHistogram) UnmarshalJSON(data []byte) error {
+	var in histogramJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	restored, err := NewLatencyHistogram(in.HighestTrackable, in.SignificantFigures)
+	if err != nil {
+		return err
+	}
+
+	for key, count := range in.Counts {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(restored.counts) {
+			return fmt.Errorf("invalid histogram counts index: %s", key)
+		}
+		restored.counts[i] = count
+	}
+	restored.totalCount = in.TotalCount
+	restored.min = in.Min
+	restored.max = in.Max
+	restored.sum = in.Sum
+
+	*h = *restored
+	return nil
+}
+
+func (h *LatencyHistogram) bucketIndex(v int64) int {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(v|h.subBucketMask))
+	return pow2Ceiling - (h.subBucketHalfCountMagnitude + 1)
+}
+
+func (h *LatencyHistogram) subBucketIndex(v int64, bucketIdx int) int {
+	return int(v >> uint(bucketIdx))
+}
+
+func (h *LatencyHistogram) countsIndex(bucketIdx, subBucketIdx int) int {
+	return ((bucketIdx + 1) << uint(h.subBucketHalfCountMagnitude)) + (subBucketIdx - h.subBucketHalfCount)
+}
+
+func (h *LatencyHistogram) countsIndexFor(v int64) int {
+	bucketIdx := h.bucketIndex(v)
+	return h.countsIndex(bucketIdx, h.subBucketIndex(v, bucketIdx))
+}
+
+func (h *LatencyHistogram) valueFromCountsIndex(i int) int64 {
+	bucketIdx := (i >> uint(h.subBucketHalfCountMagnitude)) - 1
+	subBucketIdx := (i & (h.subBucketHalfCount - 1)) + h.subBucketHalfCount
+	if bucketIdx < 0 {
+		subBucketIdx -= h.subBucketHalfCount
+		bucketIdx = 0
+	}
+	return int64(subBucketIdx) << uint(bucketIdx)
+}
+
+func (h *LatencyHistogram) equivalentRangeSize(v int64) int64 {
+	bucketIdx := h.bucketIndex(v)
+	if h.subBucketIndex(v, bucketIdx) >= h.subBucketCount {
+		bucketIdx++
+	}
+	return 1 << uint(bucketIdx)
+}
+
+func (h *LatencyHistogram) lowestEquivalentValue(v int64) int64 {
+	bucketIdx := h.bucketIndex(v)
+	return int64(h.subBucketIndex(v, bucketIdx)) << uint(bucketIdx)
+}
+
+func (h *LatencyHistogram) highestEquivalentValue(v int64) int64 {
+	return h.lowestEquivalentValue(v) + h.equivalentRangeSize(v) - 1
+}
+
+func (h *LatencyHistogram) medianEquivalentValue(v int64) int64 {
+	return h.lowestEquivalentValue(v) + h.equivalentRangeSize(v)/2
+}
+
+// secondsToMicros converte segundos em microssegundos, arredondando
+func secondsToMicros(seconds float64) int64 {
+	return int64(math.Round(seconds * 1e6))
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"time"
+)
+
+// KeepAliveProbe é uma sondagem do modo keepalive: uma requisição enviada após Gap sem tráfego
+type KeepAliveProbe struct {
+	Gap        time.Duration
+	Timestamp  time.Time
+	Latency    float64 // em segundos
+	StatusCode int
+	PodsBefore int // pods da função ao fim do intervalo ocioso, -1 se desconhecido
+	PodsAfter  int // pods da função logo após a resposta, -1 se desconhecido
+	Cold       bool
+	Reason     string // sinais que levaram à classificação como cold start
+	Error      string
+}
+
+// KeepAliveMetrics resume a busca pela janela de keep-alive de uma plataforma. A janela
+// fica entre o maior intervalo ainda quente e o menor intervalo já frio.
+type KeepAliveMetrics struct {
+	Platform    string
+	Function    string
+	Strategy    string
+	WarmLatency float64 // latência de referência com a instância quente, em segundos
+	Probes      []KeepAliveProbe
+
+	LastWarmGap  time.Duration
+	FirstColdGap time.Duration
+	Window       time.Duration // estimativa: ponto médio entre LastWarmGap e FirstColdGap
+	Found        bool          // alguma sondagem foi fria; sem isso a janela é maior que LastWarmGap
+}
+
+// NewKeepAliveMetrics estima a janela de keep-alive a partir das sondagens classificadas.
+// Sondagens com erro não entram na estimativa.
+func NewKeepAliveMetrics(platform, function, strategy string, warmLatency float64, probes []KeepAliveProbe) *KeepAliveMetrics {
+	m := &KeepAliveMetrics{
+		Platform:    platform,
+		Function:    function,
+		Strategy:    strategy,
+		WarmLatency: warmLatency,
+		Probes:      probes,
+	}
+
+	for _, probe := range probes {
+		if probe.Error == "" && probe.Cold && (!m.Found || probe.Gap < m.FirstColdGap) {
+			m.FirstColdGap = probe.Gap
+			m.Found = true
+		}
+	}
+
+	for _, probe := range probes {
+		if probe.Error != "" || probe.Cold || (m.Found && probe.Gap >= m.FirstColdGap) {
+			continue
+		}
+		if probe.Gap > m.LastWarmGap {
+			m.LastWarmGap = probe.Gap
+		}
+	}
+
+	if m.Found {
+		m.Window = (m.LastWarmGap + m.FirstColdGap) / 2
+	} else {
+		m.Window = m.LastWarmGap
+	}
+
+	return m
+}
@@ -1,19 +1,18 @@
 package metrics
 
 import (
-	"context"
-	"fmt"
-	"io"
-	"net/http"
-	"strconv"
-	"strings"
+	"log"
 	"time"
 
 	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
 )
 
 // Estruturas para armazenar as métricas consolidadas
 type ConsolidatedMetrics struct {
+	// Identificação do ponto de varredura ou cenário, vazia em execuções simples
+	Label string
+
 	// Hey Metrics
 	AvgLatency    float64
 	P99Latency    float64
@@ -31,138 +30,363 @@ type ConsolidatedMetrics struct {
 
 	// Dados brutos para o cálculo do Cold Start
 	PodStartedAt map[string]float64 // podName: started_at_timestamp (Unix seconds)
+
+	// Fases da inicialização dos pods criados durante a carga, quando a fonte as fornece
+	Startup *StartupBreakdown
+
+	// Latências das requisições frias e quentes, quando há medições individuais
+	ColdWarm *ColdWarmMetrics
+
+	// Métricas de cada execução e agregados entre execuções
+	Executions []ExecutionMetrics
+	Aggregates AggregatedMetrics
+
+	// Histograma combinado de todas as execuções e os percentis calculados sobre ele.
+	// Quando alguma execução não tem medições individuais o histograma é aproximado,
+	// montado das faixas do resumo do hey, e não há percentis combinados.
+	LatencyHistogram   *LatencyHistogram
+	ApproximateLatency bool
+	PooledPercentiles  []heyexec.LatencyPercentile
+
+	// Estágios do perfil de carga combinados entre execuções
+	Stages []StageMetrics
+
+	// Métricas do modo open combinadas entre execuções
+	OpenLoop *OpenLoopMetrics
+
+	// Distribuição das latências de cold start no modo coldstart
+	ColdStart *ColdStartMetrics
+
+	// Estimativa da janela de keep-alive no modo keepalive
+	KeepAlive *KeepAliveMetrics
+
+	// Leituras contínuas do cluster durante a carga
+	Sampled *SampledMetrics
+
+	// Custo estimado das execuções, quando há tabela de preços
+	Cost *CostMetrics
+
+	// Consumo de recursos normalizado pela carga, combinado entre execuções
+	Efficiency *EfficiencyMetrics
+}
+
+// OpenLoopMetrics separa, no modo open, o tempo de resposta medido a partir do
+// horário planejado do tempo de serviço medido a partir do envio efetivo
+type OpenLoopMetrics struct {
+	AvgResponseTime    float64
+	P99ResponseTime    float64
+	AvgServiceTime     float64
+	P99ServiceTime     float64
+	AvgSchedulingDelay float64
+	MaxSchedulingDelay float64
+	LateRequests       int // Requisições enviadas mais de 1 ms após o horário planejado
+}
+
+// StageMetrics resume um estágio do perfil de carga sobre todas as execuções
+type StageMetrics struct {
+	Index       int
+	Name        string
+	Type        string
+	Start       float64 // em segundos, relativo ao início da execução
+	End         float64
+	TargetRPS   float64
+	AchievedRPS Stats // Taxa atingida em cada execução
+	Requests    int
+	ErrorRate   float64
+	AvgLatency  float64
+	P99Latency  float64 // Calculado sobre o histograma combinado do estágio
+}
+
+// ExecutionMetrics armazena as métricas do gerador de carga de uma única execução
+type ExecutionMetrics struct {
+	Execution     int // Índice da execução, começando em 1
+	StartTime     time.Time
+	EndTime       time.Time
+	AvgLatency    float64
+	P99Latency    float64
+	RPS           float64
+	ErrorRate     float64
+	TotalRequests int
+	TotalData     int
+	Error         string // Erro da execução, vazio em caso de sucesso
+
+	// Estatísticas exatas, disponíveis quando há medições individuais (CSV do hey ou gerador nativo)
+	ExactLatency  bool
+	StdDevLatency float64
+	MaxLatency    float64
+	Percentiles   []heyexec.LatencyPercentile
+
+	// Histograma de latência da execução, combinável com o das demais
+	Histogram *LatencyHistogram
+
+	// Resumo por estágio, quando há perfil de carga
+	Stages []heyexec.StageResult
+
+	// Tempo de serviço e atraso de envio, apenas no modo open
+	OpenLoop *OpenLoopMetrics
+
+	// Estado da função no cluster antes e depois da execução
+	Snapshot *SnapshotDelta
+
+	// Métricas do cluster na janela da execução
+	Cluster *ClusterMetrics
+
+	// Pausa feita antes da execução, nil na primeira ou sem política configurada
+	Cooldown *heyexec.CooldownResult
+
+	// Reação do autoscaler à execução, a partir das réplicas amostradas
+	Autoscaling *AutoscalingTimeline
+
+	// Custo estimado da execução com a tabela de preços configurada
+	Cost *CostEstimate
+
+	// Consumo de recursos da execução normalizado pela carga
+	Efficiency *EfficiencyMetrics
 }
 
-// PostProcessor é responsável por coletar métricas do exporter e consolidar os resultados
+// AggregatedMetrics resume as métricas do gerador de carga entre todas as execuções bem-sucedidas
+type AggregatedMetrics struct {
+	RPS        Stats
+	AvgLatency Stats
+	P99Latency Stats
+	ErrorRate  Stats
+
+	// Percentis configurados, agregados entre as execuções com medições individuais
+	Percentiles []PercentileStats
+}
+
+// PercentileStats resume um percentil de latência entre execuções
+type PercentileStats struct {
+	Percentile float64 // 0-100
+	Stats
+}
+
+// PostProcessor consolida os resultados do gerador de carga com as métricas do
+// cluster, qualquer que seja a origem (MetricsSource) dessas métricas
 type PostProcessor struct {
-	exporterURL string
-	httpClient  *http.Client
+	percentiles []float64
+	snapshots   map[int]*SnapshotDelta
+	clusters    map[int]*ClusterMetrics
+	pricing     *parameters.CostParameters
 }
 
 // NewPostProcessor cria uma nova instância do PostProcessor
-func NewPostProcessor(exporterURL string) *PostProcessor {
-	return &PostProcessor{
-		exporterURL: exporterURL,
-		httpClient:  &http.Client{Timeout: 10 * time.Second},
-	}
+func NewPostProcessor() *PostProcessor {
+	return &PostProcessor{}
 }
 
-// CollectMetrics coleta as métricas do endpoint Prometheus do exporter
-func (p *PostProcessor) CollectMetrics(ctx context.Context) (ConsolidatedMetrics, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", p.exporterURL, nil)
-	if err != nil {
-		return ConsolidatedMetrics{}, fmt.Errorf("failed to create request: %w", err)
-	}
+// SetPercentiles define os percentis de latência (0-100) calculados para cada execução
+func (p *PostProcessor) SetPercentiles(percentiles []float64) {
+	p.percentiles = percentiles
+}
 
-	resp, err := p.httpClient.Do(req)
-	if err != nil {
-		return ConsolidatedMetrics{}, fmt.Errorf("failed to reach exporter at %s: %w", p.exporterURL, err)
-	}
-	defer resp.Body.Close()
+// SetSnapshots define os snapshots de antes e depois de cada execução, indexados
+// pela execução (começando em 1), como registrados pelo ExecutionTracker
+func (p *PostProcessor) SetSnapshots(snapshots map[int]*SnapshotDelta) {
+	p.snapshots = snapshots
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return ConsolidatedMetrics{}, fmt.Errorf("exporter returned non-200 status: %d", resp.StatusCode)
-	}
+// SetClusters define as métricas do cluster coletadas na janela de cada execução,
+// indexadas pela execução (começando em 1), como registradas pelo ExecutionTracker
+func (p *PostProcessor) SetClusters(clusters map[int]*ClusterMetrics) {
+	p.clusters = clusters
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return ConsolidatedMetrics{}, fmt.Errorf("failed to read exporter response body: %w", err)
+// SetPricing define a tabela de preços usada para estimar o custo de cada execução;
+// sem nenhum preço configurado o custo não é calculado
+func (p *PostProcessor) SetPricing(pricing parameters.CostParameters) {
+	p.pricing = nil
+	if pricing.Enabled() {
+		p.pricing = &pricing
 	}
-
-	return p.parsePrometheusMetrics(string(body))
 }
 
-// parsePrometheusMetrics extrai os valores das métricas desejadas do formato Prometheus
-func (p *PostProcessor) parsePrometheusMetrics(metricsBody string) (ConsolidatedMetrics, error) {
-	metrics := ConsolidatedMetrics{
-		PodStartedAt: make(map[string]float64),
-	}
+// ConsolidateResults combina os resultados do hey com as métricas do cluster e calcula o Cold Start
+func (p *PostProcessor) ConsolidateResults(heyResults []*heyexec.RunResult, collectedMetrics ConsolidatedMetrics, benchmarkStartTime time.Time) ConsolidatedMetrics {
 
-	// Variáveis auxiliares para o cálculo de Cold Start
-	//TimeInicialization := []float64{}
+	// 1. Consolidar Hey Metrics de todas as execuções
+	var rpsValues, avgLatencyValues, p99Values, errorRateValues []float64
+	percentileValues := make([][]float64, len(p.percentiles))
+	merged := NewDefaultLatencyHistogram()
+	exact := true
 
-	lines := strings.Split(metricsBody, "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+	collectedMetrics.Executions = make([]ExecutionMetrics, 0, len(heyResults))
+	for i, run := range heyResults {
+		if run == nil {
 			continue
 		}
 
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
+		execution := executionMetricsFromRun(i+1, run, p.percentiles)
+		execution.Snapshot = p.snapshots[i+1]
+		execution.Cluster = p.clusters[i+1]
+
+		if c := execution.Cluster; c != nil {
+			// Sem série da própria fonte, usa as leituras contínuas feitas durante a
+			// execução; o uso da execução passa a ser a média delas, e não a leitura
+			// única que a fonte faz ao fim da execução
+			if c.Sampled == nil && collectedMetrics.Sampled != nil {
+				c.Sampled = collectedMetrics.Sampled.Slice(c.Window)
+				if c.Error == "" && len(c.Sampled.Samples) > 0 {
+					c.ClusterCPUUsage = c.Sampled.CPU.Avg
+					c.ClusterMemUsage = c.Sampled.Memory.Avg
+				}
+			}
+			// A variação de réplicas da execução vem dos snapshots, e não da referência da fonte
+			if snap := execution.Snapshot; snap != nil && snap.Error == "" {
+				c.ScaledPodsDiff = snap.PodDelta
+			}
+		}
+		collectedMetrics.Executions = append(collectedMetrics.Executions, execution)
+
+		if execution.Error != "" {
 			continue
 		}
 
-		metricNameAndLabels := parts[0]
-		valueStr := parts[1]
+		rpsValues = append(rpsValues, execution.RPS)
+		avgLatencyValues = append(avgLatencyValues, execution.AvgLatency)
+		p99Values = append(p99Values, execution.P99Latency)
+		errorRateValues = append(errorRateValues, execution.ErrorRate)
 
-		value, err := strconv.ParseFloat(valueStr, 64)
-		if err != nil {
-			// Ignora linhas com valores não numéricos
-			continue
+		for j, pct := range execution.Percentiles {
+			percentileValues[j] = append(percentileValues[j], pct.Latency)
 		}
 
-		if strings.HasPrefix(metricNameAndLabels, "kubernetes_cluster_cpu_usage_millicores") {
-			metrics.ClusterCPUUsage = value
-		} else if strings.HasPrefix(metricNameAndLabels, "kubernetes_cluster_memory_usage_bytes") {
-			metrics.ClusterMemUsage = value
-		} else if strings.HasPrefix(metricNameAndLabels, "serverless_pod_scaled_difference") {
-			metrics.ScaledPodsDiff = int(value)
-		} else if strings.HasPrefix(metricNameAndLabels, "serverless_pod_container_started_at_seconds") {
-			// Exemplo de linha: serverless_pod_container_started_at_seconds{namespace="default",pod="func-xxx",function="myfunc"} 1678886400.123
-			// Extrai o nome do pod do label
-			start := strings.Index(metricNameAndLabels, "pod=\"")
-			end := strings.Index(metricNameAndLabels[start+5:], "\"")
-			if start != -1 && end != -1 {
-				podName := metricNameAndLabels[start+5 : start+5+end]
-				metrics.PodStartedAt[podName] = value
-			}
+		exact = exact && execution.ExactLatency
+		if err := merged.Merge(execution.Histogram); err != nil {
+			log.Printf("Aviso: histograma da execução %d não combinado: %v", execution.Execution, err)
 		}
+
+		collectedMetrics.TotalRequests += execution.TotalRequests
+		collectedMetrics.TotalData += execution.TotalData
 	}
 
-	return metrics, nil
-}
+	// A variação de réplicas do cenário vem do snapshot de antes da primeira
+	// execução e do de depois da última, e não da referência mantida pela fonte
+	if diff, ok := scenarioPodDelta(collectedMetrics.Executions); ok {
+		collectedMetrics.ScaledPodsDiff = diff
+	}
 
-// ConsolidateResults combina os resultados do hey com as métricas do exporter e calcula o Cold Start
-func (p *PostProcessor) ConsolidateResults(heyResults []*heyexec.RunResult, collectedMetrics ConsolidatedMetrics, benchmarkStartTime time.Time) ConsolidatedMetrics {
+	collectedMetrics.Aggregates = AggregatedMetrics{
+		RPS:        NewStats(rpsValues),
+		AvgLatency: NewStats(avgLatencyValues),
+		P99Latency: NewStats(p99Values),
+		ErrorRate:  NewStats(errorRateValues),
+	}
 
-	// 1. Consolidar Hey Metrics (assumindo apenas a primeira execução para simplificar)
-	if len(heyResults) > 0 && heyResults[0].HeyOutput != nil {
-		firstRun := heyResults[0].HeyOutput
+	for j, values := range percentileValues {
+		if len(values) == 0 {
+			continue
+		}
+		collectedMetrics.Aggregates.Percentiles = append(collectedMetrics.Aggregates.Percentiles, PercentileStats{
+			Percentile: p.percentiles[j],
+			Stats:      NewStats(values),
+		})
+	}
 
-		// RPS
-		collectedMetrics.RPS = firstRun.RequestsPerSecond
+	// Os valores principais passam a ser as médias entre execuções
+	collectedMetrics.RPS = collectedMetrics.Aggregates.RPS.Mean
+	collectedMetrics.AvgLatency = collectedMetrics.Aggregates.AvgLatency.Mean
+	collectedMetrics.P99Latency = collectedMetrics.Aggregates.P99Latency.Mean
+	collectedMetrics.ErrorRate = collectedMetrics.Aggregates.ErrorRate.Mean
+
+	// Percentis de várias execuções não podem ser combinados pela média; com
+	// medições individuais em todas as execuções o p99 reportado é o da população
+	// completa. Sem elas o histograma vem das faixas do resumo do hey, e o p99 do
+	// próprio hey, na média entre execuções, é mais preciso que o limite da faixa.
+	if merged.TotalCount() > 0 {
+		collectedMetrics.LatencyHistogram = merged
+		collectedMetrics.ApproximateLatency = !exact
+	}
+	if merged.TotalCount() > 0 && exact {
+		collectedMetrics.P99Latency = merged.ValueAtPercentile(99)
+		for _, pct := range p.percentiles {
+			collectedMetrics.PooledPercentiles = append(collectedMetrics.PooledPercentiles, heyexec.LatencyPercentile{
+				Percentage: pct / 100,
+				Latency:    merged.ValueAtPercentile(pct),
+			})
+		}
+	}
 
-		// Latência Média (AvgLatency)
-		collectedMetrics.AvgLatency = firstRun.Summary.Average
+	// Reação do autoscaler a cada execução, da execução até o início da próxima; a
+	// última inclui as leituras feitas depois da carga, enquanto as réplicas voltavam
+	if sm := collectedMetrics.Sampled; sm != nil {
+		series := append(append([]MetricSample(nil), sm.Samples...), sm.ScaleDown...)
+		for i := range collectedMetrics.Executions {
+			execution := &collectedMetrics.Executions[i]
+			if execution.StartTime.IsZero() {
+				continue
+			}
 
-		// Latência P99 (procura no LatencyDistribution)
-		for _, dist := range firstRun.LatencyDistribution {
-			if dist.Percentage >= 0.99 {
-				collectedMetrics.P99Latency = dist.Latency
-				break
+			var until time.Time
+			if i+1 < len(collectedMetrics.Executions) {
+				until = collectedMetrics.Executions[i+1].StartTime
 			}
+			baseline := -1
+			if snap := execution.Snapshot; snap != nil && snap.Error == "" {
+				baseline = snap.Before.Pods
+			}
+			execution.Autoscaling = NewAutoscalingTimeline(series, baseline, execution.StartTime, execution.EndTime, until)
 		}
+	}
 
-		// Taxa de Erros e Falhas
-		collectedMetrics.TotalRequests = firstRun.Requests
-		collectedMetrics.TotalData = firstRun.BytesTotal
-
-		// Taxa de Erros (assumindo que o hey não fornece um campo direto, calculamos a partir do status code)
-		// Isso é uma estimativa, pois o hey fornece o Status Code Distribution.
-		// Assumindo que 2xx são sucesso e 4xx/5xx são falhas.
-		errorCount := 0
-		for codeStr, count := range firstRun.StatusCodeDist {
-			if len(codeStr) == 3 && (codeStr[0] == '4' || codeStr[0] == '5') {
-				errorCount += count
+	// Eficiência de cada execução sobre a janela da carga, com a série do cenário
+	// ou, sem ela, a série coletada na janela da execução. O custo também cobre as
+	// réplicas que sobram depois da carga: até o início da execução seguinte ou, na
+	// última, até a última leitura da redução de réplicas.
+	var samples, lifetimes []MetricSample
+	if sm := collectedMetrics.Sampled; sm != nil {
+		samples = sm.Samples
+		lifetimes = append(append([]MetricSample(nil), sm.Samples...), sm.ScaleDown...)
+	}
+	for i := range collectedMetrics.Executions {
+		execution := &collectedMetrics.Executions[i]
+		if execution.StartTime.IsZero() || execution.EndTime.IsZero() {
+			continue
+		}
+		executionSamples, costSamples := samples, lifetimes
+		until := execution.EndTime
+		if executionSamples == nil {
+			if execution.Cluster != nil && execution.Cluster.Sampled != nil {
+				executionSamples = execution.Cluster.Sampled.Samples
+				costSamples = executionSamples
 			}
+		} else if i+1 < len(collectedMetrics.Executions) && !collectedMetrics.Executions[i+1].StartTime.IsZero() {
+			until = collectedMetrics.Executions[i+1].StartTime
+		} else if scaleDown := collectedMetrics.Sampled.ScaleDown; i+1 == len(collectedMetrics.Executions) && len(scaleDown) > 0 {
+			until = scaleDown[len(scaleDown)-1].Timestamp
 		}
 
-		if firstRun.Requests > 0 {
-			collectedMetrics.ErrorRate = float64(errorCount) / float64(firstRun.Requests)
+		if p.pricing != nil {
+			cost := NewCostEstimate(*p.pricing, costSamples, execution.StartTime, execution.EndTime, until, execution.TotalRequests)
+			execution.Cost = &cost
 		}
+		execution.Efficiency = NewEfficiencyMetrics(executionSamples, execution.StartTime, execution.EndTime,
+			execution.TotalRequests, executionResources(execution.Snapshot))
+	}
+	if p.pricing != nil {
+		collectedMetrics.Cost = NewCostMetrics(*p.pricing, collectedMetrics.Executions)
+	}
+	collectedMetrics.Efficiency = NewEfficiencySummary(collectedMetrics.Executions)
+
+	collectedMetrics.Stages = consolidateStages(heyResults)
+
+	// Requisições frias e quentes, pela inicialização dos pods novos e pelo ajuste bimodal
+	var startupPods []PodStartup
+	if collectedMetrics.Startup != nil {
+		startupPods = collectedMetrics.Startup.Pods
+	}
+	collectedMetrics.ColdWarm = ClassifyRequests(heyResults, startupPods, p.percentiles)
 
-		// Falhas de conexão (Hey não expõe diretamente, mas pode ser inferido do ErrorDist)
-		// Vamos simplificar e usar a taxa de erros HTTP como principal indicador.
+	var openLoopRecords []heyexec.RequestRecord
+	for _, run := range heyResults {
+		if run != nil && run.OpenLoop {
+			openLoopRecords = append(openLoopRecords, run.Records...)
+		}
+	}
+	collectedMetrics.OpenLoop = openLoopMetrics(openLoopRecords)
+
+	if len(collectedMetrics.Executions) > 0 {
+		collectedMetrics.FailureRate = float64(len(collectedMetrics.Executions)-len(rpsValues)) / float64(len(collectedMetrics.Executions))
 	}
 
 	// 2. Calcular Cold Start Time
@@ -189,3 +413,226 @@ func (p *PostProcessor) ConsolidateResults(heyResults []*heyexec.RunResult, coll
 
 	return collectedMetrics
 }
+
+// executionResources retorna a especificação das réplicas lida nos snapshots da
+// execução, preferindo a do fim da carga, quando a função já escalou
+func executionResources(snap *SnapshotDelta) *PodResources {
+	if snap == nil {
+		return nil
+	}
+	if snap.After.Resources != nil {
+		return snap.After.Resources
+	}
+	return snap.Before.Resources
+}
+
+// executionMetricsFromRun extrai as métricas do gerador de carga de uma execução
+func executionMetricsFromRun(index int, run *heyexec.RunResult, percentiles []float64) ExecutionMetrics {
+	execution := ExecutionMetrics{
+		Execution: index,
+		StartTime: run.StartTime,
+		EndTime:   run.EndTime,
+		Cooldown:  run.Cooldown,
+	}
+
+	if run.HeyOutput == nil {
+		if run.Error != nil {
+			execution.Error = run.Error.Error()
+		} else {
+			execution.Error = "no load generator output"
+		}
+		return execution
+	}
+
+	output := run.HeyOutput
+
+	execution.RPS = output.RequestsPerSecond
+	execution.AvgLatency = output.Summary.Average
+	execution.TotalRequests = output.Requests
+	execution.TotalData = output.BytesTotal
+
+	if stats, ok := run.LatencyStats(append([]float64{99}, percentiles...)); ok {
+		// Com medições individuais os percentis são exatos; o primeiro é sempre o p99
+		execution.ExactLatency = true
+		execution.AvgLatency = stats.Mean
+		execution.StdDevLatency = stats.StdDev
+		execution.MaxLatency = stats.Max
+		execution.P99Latency = stats.Percentiles[0].Latency
+		execution.Percentiles = stats.Percentiles[1:]
+	} else {
+		// Sem medições individuais, procura o P99 no LatencyDistribution do resumo
+		for _, dist := range output.LatencyDistribution {
+			if dist.Percentage >= 0.99 {
+				execution.P99Latency = dist.Latency
+				break
+			}
+		}
+	}
+
+	execution.Histogram = histogramFromRun(run)
+	execution.Stages = run.Stages
+	if run.OpenLoop {
+		execution.OpenLoop = openLoopMetrics(run.Records)
+	}
+
+	// Taxa de Erros calculada a partir da distribuição de status code.
+	// Assumindo que 2xx são sucesso e 4xx/5xx são falhas.
+	errorCount := 0
+	for codeStr, count := range output.StatusCodeDist {
+		if len(codeStr) == 3 && (codeStr[0] == '4' || codeStr[0] == '5') {
+			errorCount += count
+		}
+	}
+
+	if output.Requests > 0 {
+		execution.ErrorRate = float64(errorCount) / float64(output.Requests)
+	}
+
+	return execution
+}
+
+// histogramFromRun preenche o histograma de latência de uma execução. Com medições
+// individuais cada latência é registrada; caso contrário, usa as faixas do resumo
+// do hey, registrando cada contagem no limite superior da sua faixa (aproximação).
+func histogramFromRun(run *heyexec.RunResult) *LatencyHistogram {
+	h := NewDefaultLatencyHistogram()
+	var rejected rejectedLatencies
+
+	if len(run.Records) > 0 {
+		for _, r := range run.Records {
+			if r.Error == "" {
+				rejected.add(h.RecordSeconds(r.ResponseTime))
+			}
+		}
+	} else if run.HeyOutput != nil {
+		for _, bucket := range run.HeyOutput.Histogram {
+			rejected.add(h.RecordValues(secondsToMicros(bucket.Mark), int64(bucket.Count)))
+		}
+	}
+	rejected.warn("da execução")
+
+	if h.TotalCount() == 0 {
+		return nil
+	}
+	return h
+}
+
+// consolidateStages combina os estágios do perfil de carga de todas as execuções,
+// agrupando as medições individuais pelo índice do estágio
+func consolidateStages(heyResults []*heyexec.RunResult) []StageMetrics {
+	var stages []StageMetrics
+	var achieved [][]float64
+	var histograms []*LatencyHistogram
+	var errors []int
+	var rejected rejectedLatencies
+
+	for _, run := range heyResults {
+		if run == nil || len(run.Stages) == 0 {
+			continue
+		}
+
+		for len(stages) < len(run.Stages) {
+			s := run.Stages[len(stages)]
+			stages = append(stages, StageMetrics{
+				Index:     s.Index,
+				Name:      s.Name,
+				Type:      s.Type,
+				Start:     s.Start,
+				End:       s.End,
+				TargetRPS: s.TargetRPS,
+			})
+			achieved = append(achieved, nil)
+			histograms = append(histograms, NewDefaultLatencyHistogram())
+			errors = append(errors, 0)
+		}
+
+		for _, s := range run.Stages {
+			achieved[s.Index] = append(achieved[s.Index], s.AchievedRPS)
+			stages[s.Index].Requests += s.Requests
+			errors[s.Index] += s.Errors
+		}
+
+		for _, r := range run.Records {
+			if r.Error == "" && r.Stage >= 0 && r.Stage < len(histograms) {
+				rejected.add(histograms[r.Stage].RecordSeconds(r.ResponseTime))
+			}
+		}
+	}
+	rejected.warn("dos estágios")
+
+	for i := range stages {
+		stages[i].AchievedRPS = NewStats(achieved[i])
+		stages[i].AvgLatency = histograms[i].Mean()
+		stages[i].P99Latency = histograms[i].ValueAtPercentile(99)
+		if stages[i].Requests > 0 {
+			stages[i].ErrorRate = float64(errors[i]) / float64(stages[i].Requests)
+		}
+	}
+
+	return stages
+}
+
+// Atraso de envio a partir do qual uma requisição do modo open é considerada atrasada
+const lateRequestThreshold = 0.001
+
+// openLoopMetrics resume tempos de resposta, de serviço e atrasos de envio das
+// requisições do modo open. Retorna nil quando não há medições.
+func openLoopMetrics(records []heyexec.RequestRecord) *OpenLoopMetrics {
+	response := NewDefaultLatencyHistogram()
+	service := NewDefaultLatencyHistogram()
+	m := &OpenLoopMetrics{}
+	var rejected rejectedLatencies
+
+	delaySum := 0.0
+	for _, r := range records {
+		delaySum += r.SchedulingDelay
+		if r.SchedulingDelay > m.MaxSchedulingDelay {
+			m.MaxSchedulingDelay = r.SchedulingDelay
+		}
+		if r.SchedulingDelay > lateRequestThreshold {
+			m.LateRequests++
+		}
+
+		if r.Error == "" {
+			rejected.add(response.RecordSeconds(r.ResponseTime))
+			rejected.add(service.RecordSeconds(r.ServiceTime))
+		}
+	}
+	rejected.warn("do modo open")
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	m.AvgSchedulingDelay = delaySum / float64(len(records))
+	m.AvgResponseTime = response.Mean()
+	m.P99ResponseTime = response.ValueAtPercentile(99)
+	m.AvgServiceTime = service.Mean()
+	m.P99ServiceTime = service.ValueAtPercentile(99)
+
+	return m
+}
+
+// rejectedLatencies conta as latências recusadas por um histograma, como as acima
+// do maior valor rastreável, para avisar uma vez em vez de descartá-las em silêncio
+type rejectedLatencies struct {
+	count int
+	first error
+}
+
+func (r *rejectedLatencies) add(err error) {
+	if err == nil {
+		return
+	}
+	r.count++
+	if r.first == nil {
+		r.first = err
+	}
+}
+
+// warn avisa quantas latências ficaram fora do histograma descrito
+func (r rejectedLatencies) warn(histogram string) {
+	if r.count > 0 {
+		log.Printf("Aviso: %d latências ficaram fora do histograma %s: %v", r.count, histogram, r.first)
+	}
+}
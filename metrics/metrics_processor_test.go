@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// summaryRun é uma execução do hey sem medições individuais, apenas com o resumo
+func summaryRun(p99 float64) *heyexec.RunResult {
+	start := time.Unix(1700000000, 0)
+	return &heyexec.RunResult{
+		StartTime: start,
+		EndTime:   start.Add(10 * time.Second),
+		HeyOutput: &heyexec.HeyResult{
+			Requests:            100,
+			LatencyDistribution: []heyexec.LatencyPercentile{{Percentage: 0.9, Latency: 0.05}, {Percentage: 0.99, Latency: p99}},
+			Histogram: []heyexec.HistogramBucket{
+				{Mark: 0.01, Count: 90},
+				{Mark: 0.5, Count: 10},
+			},
+		},
+	}
+}
+
+// exactRun é uma execução com medições individuais
+func exactRun(latencies ...float64) *heyexec.RunResult {
+	run := summaryRun(0)
+	for _, l := range latencies {
+		run.Records = append(run.Records, heyexec.RequestRecord{ResponseTime: l, StatusCode: 200})
+	}
+	return run
+}
+
+func TestConsolidateResultsP99(t *testing.T) {
+	tests := []struct {
+		name        string
+		runs        []*heyexec.RunResult
+		wantP99     float64
+		approximate bool
+		pooled      bool
+	}{
+		{
+			name:        "resumo do hey mantém a média do p99 informado",
+			runs:        []*heyexec.RunResult{summaryRun(0.12), summaryRun(0.08)},
+			wantP99:     0.10,
+			approximate: true,
+		},
+		{
+			name:        "execução sem medições impede o p99 combinado",
+			runs:        []*heyexec.RunResult{exactRun(0.01, 0.02, 0.03), summaryRun(0.2)},
+			wantP99:     (0.03 + 0.2) / 2,
+			approximate: true,
+		},
+		{
+			name:    "medições individuais em todas as execuções",
+			runs:    []*heyexec.RunResult{exactRun(0.01, 0.02), exactRun(0.04, 0.3)},
+			wantP99: 0.3,
+			pooled:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPostProcessor()
+			p.SetPercentiles([]float64{50})
+			m := p.ConsolidateResults(tt.runs, ConsolidatedMetrics{}, time.Unix(1700000000, 0))
+
+			// O histograma arredonda para 3 dígitos significativos
+			if math.Abs(m.P99Latency-tt.wantP99) > tt.wantP99*0.001 {
+				t.Errorf("P99Latency = %g, want %g", m.P99Latency, tt.wantP99)
+			}
+			if m.LatencyHistogram == nil {
+				t.Fatal("LatencyHistogram = nil")
+			}
+			if m.ApproximateLatency != tt.approximate {
+				t.Errorf("ApproximateLatency = %t, want %t", m.ApproximateLatency, tt.approximate)
+			}
+			if got := len(m.PooledPercentiles) > 0; got != tt.pooled {
+				t.Errorf("PooledPercentiles = %v, want pooled %t", m.PooledPercentiles, tt.pooled)
+			}
+		})
+	}
+}
+
+func TestConsolidateResultsClusterWindowFromSamples(t *testing.T) {
+	run := summaryRun(0.1)
+	window := Window{Start: run.StartTime, End: run.EndTime}
+
+	at := func(offset time.Duration, cpu, memory float64, pods int) MetricSample {
+		return MetricSample{Timestamp: run.StartTime.Add(offset), CPU: cpu, Memory: memory, Pods: pods}
+	}
+	sampled := NewSampledMetrics(5*time.Second, []MetricSample{
+		at(-5*time.Second, 999, 999, 9), // antes da execução
+		at(0, 100, 1000, 1),
+		at(5*time.Second, 300, 3000, 3),
+		at(10*time.Second, 200, 2000, 2),
+		at(15*time.Second, 999, 999, 9), // depois da execução
+	}, 0)
+
+	p := NewPostProcessor()
+	// Leitura única feita pela fonte ao fim da execução
+	p.SetClusters(map[int]*ClusterMetrics{1: {Window: window, ClusterCPUUsage: 5, ClusterMemUsage: 50}})
+	m := p.ConsolidateResults([]*heyexec.RunResult{run}, ConsolidatedMetrics{Sampled: sampled}, run.StartTime)
+
+	c := m.Executions[0].Cluster
+	if c.ClusterCPUUsage != 200 || c.ClusterMemUsage != 2000 {
+		t.Errorf("cluster usage = %g mCores, %g bytes, want the window averages 200 and 2000", c.ClusterCPUUsage, c.ClusterMemUsage)
+	}
+	if c.Sampled.CPU.Max != 300 || len(c.Sampled.Samples) != 3 {
+		t.Errorf("window series = %d samples, max CPU %g, want 3 samples and 300", len(c.Sampled.Samples), c.Sampled.CPU.Max)
+	}
+}
+
+func TestConsolidateResultsScenarioPodDelta(t *testing.T) {
+	runs := []*heyexec.RunResult{summaryRun(0.1), summaryRun(0.1), summaryRun(0.1)}
+	snapshot := func(before, after int) *SnapshotDelta {
+		return NewSnapshotDelta(ClusterSnapshot{Pods: before}, ClusterSnapshot{Pods: after})
+	}
+
+	tests := []struct {
+		name      string
+		snapshots map[int]*SnapshotDelta
+		want      int
+	}{
+		{
+			name:      "first before and last after",
+			snapshots: map[int]*SnapshotDelta{1: snapshot(1, 4), 2: snapshot(4, 3), 3: snapshot(3, 5)},
+			want:      4,
+		},
+		{
+			name:      "failed last snapshot keeps the source value",
+			snapshots: map[int]*SnapshotDelta{1: snapshot(1, 4), 3: {Error: "timeout"}},
+			want:      7,
+		},
+		{
+			name: "no snapshots keeps the source value",
+			want: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPostProcessor()
+			p.SetSnapshots(tt.snapshots)
+			m := p.ConsolidateResults(runs, ConsolidatedMetrics{ScaledPodsDiff: 7}, runs[0].StartTime)
+			if m.ScaledPodsDiff != tt.want {
+				t.Errorf("ScaledPodsDiff = %d, want %d", m.ScaledPodsDiff, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsolidateResultsCostCoversReplicaLifetimes(t *testing.T) {
+	first, second := summaryRun(0.1), summaryRun(0.1)
+	second.StartTime = first.StartTime.Add(30 * time.Second)
+	second.EndTime = second.StartTime.Add(10 * time.Second)
+
+	at := func(offset time.Duration, pods int) MetricSample {
+		return MetricSample{Timestamp: first.StartTime.Add(offset), Pods: pods}
+	}
+	sampled := NewSampledMetrics(5*time.Second, []MetricSample{
+		at(0, 2),
+		at(10*time.Second, 3), // réplicas que sobram até a segunda execução
+		at(30*time.Second, 1),
+		at(35*time.Second, 1),
+	}, 0)
+	sampled.ScaleDown = []MetricSample{at(45*time.Second, 0), at(50*time.Second, 0)}
+
+	p := NewPostProcessor()
+	p.SetPricing(parameters.CostParameters{PerVCPUSecond: 1, ReplicaVCPU: 1})
+	m := p.ConsolidateResults([]*heyexec.RunResult{first, second}, ConsolidatedMetrics{Sampled: sampled}, first.StartTime)
+
+	tests := []struct {
+		execution                  int
+		replicaSeconds, postLoadRS float64
+		postLoad                   time.Duration
+	}{
+		{execution: 1, replicaSeconds: 80, postLoadRS: 60, postLoad: 20 * time.Second}, // 2×10s + 3×20s até a segunda
+		{execution: 2, replicaSeconds: 15, postLoadRS: 5, postLoad: 10 * time.Second},  // 1×10s + 1×5s até a redução a zero
+	}
+	for _, tt := range tests {
+		cost := m.Executions[tt.execution-1].Cost
+		if cost.ReplicaSeconds != tt.replicaSeconds || cost.PostLoadReplicaSeconds != tt.postLoadRS || cost.PostLoadDuration != tt.postLoad {
+			t.Errorf("execution %d: replica-s = %g (after load %g in %s), want %g (%g in %s)", tt.execution,
+				cost.ReplicaSeconds, cost.PostLoadReplicaSeconds, cost.PostLoadDuration, tt.replicaSeconds, tt.postLoadRS, tt.postLoad)
+		}
+		if cost.Duration != 10*time.Second || cost.Total != tt.replicaSeconds || cost.PostLoad != tt.postLoadRS {
+			t.Errorf("execution %d: window %s, total %g, after load %g", tt.execution, cost.Duration, cost.Total, cost.PostLoad)
+		}
+	}
+	if m.Cost.Total.PostLoad != 65 {
+		t.Errorf("scenario cost after load = %g, want 65", m.Cost.Total.PostLoad)
+	}
+}
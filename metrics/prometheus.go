@@ -0,0 +1,372 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PromSample é uma série lida do formato de exposição do Prometheus ou do OpenMetrics
+type PromSample struct {
+	Name      string
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time // zero quando a linha não traz o instante
+}
+
+// MetricFamily agrupa as séries de uma métrica. Em histogramas e summaries as
+// séries _bucket, _sum, _count e os quantis ficam na mesma família.
+type MetricFamily struct {
+	Name    string
+	Type    string // counter, gauge, histogram, summary, untyped...
+	Help    string
+	Unit    string
+	Samples []PromSample
+}
+
+// Sufixos das séries que pertencem à família de cada tipo
+var familySuffixes = map[string][]string{
+	"counter":        {"_total", "_created"},
+	"histogram":      {"_bucket", "_sum", "_count", "_created"},
+	"gaugehistogram": {"_bucket", "_gsum", "_gcount"},
+	"summary":        {"_sum", "_count", "_created"},
+	"info":           {"_info"},
+}
+
+// ParseExposition interpreta o texto no formato de exposição do Prometheus ou, com
+// openMetrics, no formato OpenMetrics (timestamps em segundos e exemplars).
+// As famílias são retornadas na ordem em que aparecem. A interpretação é estrita:
+// uma linha de série malformada invalida a coleta inteira, com o número da linha
+// no erro, para que um exporter com defeito não produza métricas parciais em silêncio.
+func ParseExposition(text string, openMetrics bool) ([]*MetricFamily, error) {
+	var families []*MetricFamily
+	byName := make(map[string]*MetricFamily)
+
+	family := func(name string) *MetricFamily {
+		if f, ok := byName[name]; ok {
+			return f
+		}
+		f := &MetricFamily{Name: name, Type: "untyped"}
+		byName[name] = f
+		families = append(families, f)
+		return f
+	}
+
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue // comentário livre ou "# EOF"
+			}
+			_, rest, _ := strings.Cut(line[1:], fields[1])
+			rest = strings.TrimSpace(rest)
+			_, rest, _ = strings.Cut(rest, fields[2])
+			rest = strings.TrimSpace(rest)
+
+			switch fields[1] {
+			case "TYPE":
+				family(fields[2]).Type = strings.ToLower(rest)
+			case "HELP":
+				family(fields[2]).Help = unescapeHelp(rest)
+			case "UNIT":
+				family(fields[2]).Unit = rest
+			}
+			continue
+		}
+
+		sample, err := parseSampleLine(line, openMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		f := family(familyName(sample.Name, byName))
+		f.Samples = append(f.Samples, sample)
+	}
+
+	return families, nil
+}
+
+// familyName encontra a família declarada a que a série pertence pelos sufixos do seu tipo
+func familyName(sampleName string, byName map[string]*MetricFamily) string {
+	if _, ok := byName[sampleName]; ok {
+		return sampleName
+	}
+
+	for familyType, suffixes := range familySuffixes {
+		for _, suffix := range suffixes {
+			base, found := strings.CutSuffix(sampleName, suffix)
+			if f, ok := byName[base]; found && ok && f.Type == familyType {
+				return base
+			}
+		}
+	}
+
+	return sampleName
+}
+
+// parseSampleLine interpreta uma linha como metric{label="valor"} 1.5 [timestamp]
+func parseSampleLine(line string, openMetrics bool) (PromSample, error) {
+	sample := PromSample{Labels: make(map[string]string)}
+
+	i := 0
+	for i < len(line) && isNameChar(line[i], i == 0) {
+		i++
+	}
+	if i == 0 {
+		return sample, fmt.Errorf("invalid metric name in %q", line)
+	}
+	sample.Name = line[:i]
+
+	rest := strings.TrimLeft(line[i:], " \t")
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return sample, fmt.Errorf("%w in %q", err, line)
+		}
+	}
+
+	// Exemplars do OpenMetrics vêm depois de " # " e não fazem parte da série
+	if openMetrics {
+		if j := strings.Index(rest, " # "); j != -1 {
+			rest = rest[:j]
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("expected value and optional timestamp in %q", line)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("invalid value %q", fields[0])
+	}
+	sample.Value = value
+
+	if len(fields) == 2 {
+		ts, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return sample, fmt.Errorf("invalid timestamp %q", fields[1])
+		}
+		// No formato do Prometheus o timestamp é em milissegundos; no OpenMetrics, em segundos
+		if !openMetrics {
+			ts /= 1000
+		}
+		sec, frac := math.Modf(ts)
+		sample.Timestamp = time.Unix(int64(sec), int64(frac*1e9))
+	}
+
+	return sample, nil
+}
+
+// parseLabels lê os pares label="valor" até o "}" e retorna o restante da linha
+func parseLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, "}") {
+			return s[1:], nil
+		}
+
+		i := 0
+		for i < len(s) && isNameChar(s[i], i == 0) && s[i] != ':' {
+			i++
+		}
+		if i == 0 {
+			return "", fmt.Errorf("invalid label name")
+		}
+		name := s[:i]
+
+		s = strings.TrimLeft(s[i:], " \t")
+		if !strings.HasPrefix(s, "=") {
+			return "", fmt.Errorf("expected = after label %s", name)
+		}
+		s = strings.TrimLeft(s[1:], " \t")
+		if !strings.HasPrefix(s, "\"") {
+			return "", fmt.Errorf("expected quoted value for label %s", name)
+		}
+
+		var value strings.Builder
+		j := 1
+		for ; j < len(s) && s[j] != '"'; j++ {
+			if s[j] == '\\' && j+1 < len(s) {
+				j++
+				switch s[j] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[j])
+				}
+				continue
+			}
+			value.WriteByte(s[j])
+		}
+		if j >= len(s) {
+			return "", fmt.Errorf("unterminated value for label %s", name)
+		}
+		labels[name] = value.String()
+
+		s = strings.TrimLeft(s[j+1:], " \t")
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		} else if !strings.HasPrefix(s, "}") {
+			return "", fmt.Errorf("expected , or } after label %s", name)
+		}
+	}
+}
+
+// isNameChar indica se o caractere é válido em nomes de métricas
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == ':':
+		return true
+	case c >= '0' && c <= '9':
+		return !first
+	}
+	return false
+}
+
+// unescapeHelp desfaz os escapes \\ e \n do texto de HELP
+func unescapeHelp(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(s)
+}
+
+// Selector filtra séries pelos valores dos labels. Valores vazios não filtram e
+// séries sem o label não são excluídas por ele, já que nem toda métrica traz
+// function, namespace e platform.
+type Selector map[string]string
+
+// Matches indica se os labels da série são compatíveis com o seletor
+func (s Selector) Matches(labels map[string]string) bool {
+	for name, want := range s {
+		if want == "" {
+			continue
+		}
+		if got, ok := labels[name]; ok && got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// FindFamily retorna a família com o nome informado, ou nil
+func FindFamily(families []*MetricFamily, name string) *MetricFamily {
+	for _, f := range families {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Select retorna as séries da família com o nome informado que atendem ao seletor
+func (f *MetricFamily) Select(name string, selector Selector) []PromSample {
+	if f == nil {
+		return nil
+	}
+
+	var selected []PromSample
+	for _, s := range f.Samples {
+		if s.Name == name && selector.Matches(s.Labels) {
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
+
+// PromBucket é um limite cumulativo de histograma somado entre as séries selecionadas
+type PromBucket struct {
+	UpperBound float64
+	Count      float64
+}
+
+// Buckets soma por limite "le" as séries _bucket de um histograma que atendem ao seletor
+func (f *MetricFamily) Buckets(selector Selector) []PromBucket {
+	if f == nil {
+		return nil
+	}
+
+	counts := make(map[float64]float64)
+	for _, s := range f.Select(f.Name+"_bucket", selector) {
+		le, err := strconv.ParseFloat(s.Labels["le"], 64)
+		if err != nil {
+			continue
+		}
+		counts[le] += s.Value
+	}
+
+	buckets := make([]PromBucket, 0, len(counts))
+	for le, count := range counts {
+		buckets = append(buckets, PromBucket{UpperBound: le, Count: count})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].UpperBound < buckets[j].UpperBound })
+	return buckets
+}
+
+// Quantiles retorna os quantis de um summary por valor do label "quantile", da série
+// selecionada. Com várias séries selecionadas vale o maior valor de cada quantil.
+func (f *MetricFamily) Quantiles(selector Selector) map[float64]float64 {
+	if f == nil {
+		return nil
+	}
+
+	quantiles := make(map[float64]float64)
+	for _, s := range f.Select(f.Name, selector) {
+		q, err := strconv.ParseFloat(s.Labels["quantile"], 64)
+		if err != nil {
+			continue
+		}
+		if current, ok := quantiles[q]; !ok || s.Value > current {
+			quantiles[q] = s.Value
+		}
+	}
+	return quantiles
+}
+
+// SelectSeries retorna, de todas as famílias, as séries com o nome informado que atendem ao seletor
+func SelectSeries(families []*MetricFamily, name string, selector Selector) []PromSample {
+	var selected []PromSample
+	for _, f := range families {
+		selected = append(selected, f.Select(name, selector)...)
+	}
+	return selected
+}
+
+// hasSeries indica se alguma família tem séries com o nome informado
+func hasSeries(families []*MetricFamily, name string) bool {
+	return len(SelectSeries(families, name, nil)) > 0
+}
+
+// aggregateSamples aplica a agregação (sum, avg ou max) aos valores das séries
+func aggregateSamples(samples []PromSample, aggregation string) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	result := 0.0
+	switch aggregation {
+	case "max":
+		result = samples[0].Value
+		for _, s := range samples[1:] {
+			result = math.Max(result, s.Value)
+		}
+	case "avg":
+		for _, s := range samples {
+			result += s.Value
+		}
+		result /= float64(len(samples))
+	default:
+		for _, s := range samples {
+			result += s.Value
+		}
+	}
+	return result
+}
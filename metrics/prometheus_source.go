@@ -0,0 +1,393 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// Número máximo de pontos por série aceito pelo Prometheus em query_range
+const maxRangePoints = 11000
+
+// PrometheusSource consulta a API HTTP de um Prometheus existente no lugar do
+// exporter, com as consultas PromQL configuradas em metrics.prometheus
+type PrometheusSource struct {
+	Parameters *parameters.BenchmarkParameters
+
+	httpClient *http.Client
+	step       time.Duration
+}
+
+// NewPrometheusSource cria uma nova instância do PrometheusSource
+func NewPrometheusSource(params *parameters.BenchmarkParameters) *PrometheusSource {
+	step, _ := time.ParseDuration(params.Metrics.SampleInterval)
+	return &PrometheusSource{
+		Parameters: params,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		step:       step,
+	}
+}
+
+// PromPoint é um ponto de uma série retornada pelo Prometheus
+type PromPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Collect consulta CPU, memória e réplicas ao longo da janela da carga. Os campos
+// de uso são as médias na janela, ScaledPodsDiff é a variação de réplicas entre o
+// início e o fim e a série completa fica em Sampled.
+func (s *PrometheusSource) Collect(ctx context.Context, window Window) (ConsolidatedMetrics, error) {
+	collected := ConsolidatedMetrics{
+		PodStartedAt: make(map[string]float64),
+	}
+	if !window.Valid() {
+		return collected, fmt.Errorf("prometheus source requires the benchmark window")
+	}
+
+	step := max(s.step, window.Duration()/maxRangePoints, time.Second)
+	queries := s.Parameters.Metrics.Prometheus
+
+	cpu, err := s.QueryRange(ctx, s.expand(queries.CPUUsage, ""), window.Start, window.End, step)
+	if err != nil {
+		return collected, err
+	}
+	memory, err := s.QueryRange(ctx, s.expand(queries.MemoryUsage, ""), window.Start, window.End, step)
+	if err != nil {
+		return collected, err
+	}
+	pods, err := s.QueryRange(ctx, s.expand(queries.PodCount, ""), window.Start, window.End, step)
+	if err != nil {
+		return collected, err
+	}
+
+	samples := mergeRangeSeries(cpu, memory, pods)
+	collected.Sampled = NewSampledMetrics(step, samples, 0)
+	collected.ClusterCPUUsage = collected.Sampled.CPU.Avg
+	collected.ClusterMemUsage = collected.Sampled.Memory.Avg
+	if len(pods) > 0 {
+		collected.ScaledPodsDiff = int(pods[len(pods)-1].Value - pods[0].Value)
+	}
+
+	return collected, nil
+}
+
+// PodCount retorna o número atual de réplicas da função segundo a consulta pod_count
+func (s *PrometheusSource) PodCount(ctx context.Context, function string) (int, error) {
+	value, err := s.Query(ctx, s.expand(s.Parameters.Metrics.Prometheus.PodCount, function))
+	return int(value), err
+}
+
+// Sample consulta os valores atuais de CPU, memória e réplicas da função
+func (s *PrometheusSource) Sample(ctx context.Context, function string) (MetricSample, error) {
+	queries := s.Parameters.Metrics.Prometheus
+	sample := MetricSample{Timestamp: time.Now()}
+
+	var err error
+	if sample.CPU, err = s.Query(ctx, s.expand(queries.CPUUsage, function)); err != nil {
+		return sample, err
+	}
+	if sample.Memory, err = s.Query(ctx, s.expand(queries.MemoryUsage, function)); err != nil {
+		return sample, err
+	}
+	pods, err := s.Query(ctx, s.expand(queries.PodCount, function))
+	sample.Pods = int(pods)
+
+	return sample, err
+}
+
+// PodNames retorna os pods da função, lidos do label pod das séries da consulta pod_names
+func (s *PrometheusSource) PodNames(ctx context.Context, function string) ([]string, error) {
+	series, err := s.vector(ctx, s.expand(s.Parameters.Metrics.Prometheus.PodNames, function))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, sample := range series {
+		if pod := sample.Labels["pod"]; pod != "" && !seen[pod] {
+			seen[pod] = true
+			names = append(names, pod)
+		}
+	}
+	return names, nil
+}
+
+// PodResources lê os requests e limits declarados nos pods da função pelas consultas
+// resource_requests e resource_limits. Como na fonte kubernetes, cada recurso é a
+// média, entre os pods que o declaram em todos os containers listados pela consulta
+// container_info, da soma dos containers do pod.
+func (s *PrometheusSource) PodResources(ctx context.Context, function string) (PodResources, error) {
+	var resources PodResources
+	queries := s.Parameters.Metrics.Prometheus
+
+	info, err := s.vector(ctx, s.expand(queries.ContainerInfo, function))
+	if err != nil {
+		return resources, err
+	}
+	requests, err := s.vector(ctx, s.expand(queries.ResourceRequests, function))
+	if err != nil {
+		return resources, err
+	}
+	limits, err := s.vector(ctx, s.expand(queries.ResourceLimits, function))
+	if err != nil {
+		return resources, err
+	}
+
+	containers := podContainers(info)
+	resources.Pods = len(containers)
+
+	resources.CPURequest = podResourceMean(requests, containers, "cpu") * 1000
+	resources.CPULimit = podResourceMean(limits, containers, "cpu") * 1000
+	resources.MemoryRequest = podResourceMean(requests, containers, "memory")
+	resources.MemoryLimit = podResourceMean(limits, containers, "memory")
+
+	return resources, nil
+}
+
+// podContainers conta os containers distintos de cada pod nas séries com os
+// labels pod e container
+func podContainers(series []PromSample) map[string]int {
+	seen := make(map[[2]string]bool)
+	containers := make(map[string]int)
+	for _, sample := range series {
+		key := [2]string{sample.Labels["pod"], sample.Labels["container"]}
+		if key[0] == "" || key[1] == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		containers[key[0]]++
+	}
+	return containers
+}
+
+// podResourceMean soma por pod as séries do recurso e retorna a média entre os
+// pods em que todos os containers declaram o recurso. Pods ausentes de containers
+// ficam de fora, já que não é possível saber se a soma está completa.
+func podResourceMean(series []PromSample, containers map[string]int, resource string) float64 {
+	perPod := make(map[string]float64)
+	declared := make(map[string]map[string]bool)
+	for _, sample := range series {
+		pod, container := sample.Labels["pod"], sample.Labels["container"]
+		if pod == "" || container == "" || sample.Labels["resource"] != resource {
+			continue
+		}
+		if declared[pod] == nil {
+			declared[pod] = make(map[string]bool)
+		}
+		declared[pod][container] = true
+		perPod[pod] += sample.Value
+	}
+
+	total, count := 0.0, 0
+	for pod, value := range perPod {
+		if want := containers[pod]; want == 0 || len(declared[pod]) < want {
+			continue
+		}
+		total += value
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// Query executa uma consulta instantânea e soma o valor de todas as séries do resultado
+func (s *PrometheusSource) Query(ctx context.Context, query string) (float64, error) {
+	series, err := s.vector(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	for _, sample := range series {
+		sum += sample.Value
+	}
+	return sum, nil
+}
+
+// vector executa uma consulta instantânea e retorna cada série com seus labels
+func (s *PrometheusSource) vector(ctx context.Context, query string) ([]PromSample, error) {
+	var data struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  [2]interface{}    `json:"value"`
+		} `json:"result"`
+	}
+	if err := s.get(ctx, "/api/v1/query", url.Values{"query": {query}}, &data); err != nil {
+		return nil, err
+	}
+
+	series := make([]PromSample, 0, len(data.Result))
+	for _, result := range data.Result {
+		point, err := parsePromPoint(result.Value)
+		if err != nil {
+			return nil, err
+		}
+		series = append(series, PromSample{
+			Name:      result.Metric["__name__"],
+			Labels:    result.Metric,
+			Value:     point.Value,
+			Timestamp: point.Timestamp,
+		})
+	}
+	return series, nil
+}
+
+// QueryRange executa uma consulta query_range e soma as séries do resultado em cada instante
+func (s *PrometheusSource) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]PromPoint, error) {
+	params := url.Values{
+		"query": {query},
+		"start": {formatPromTime(start)},
+		"end":   {formatPromTime(end)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+
+	var data struct {
+		Result []struct {
+			Values [][2]interface{} `json:"values"`
+		} `json:"result"`
+	}
+	if err := s.get(ctx, "/api/v1/query_range", params, &data); err != nil {
+		return nil, err
+	}
+
+	sums := make(map[time.Time]float64)
+	for _, series := range data.Result {
+		for _, value := range series.Values {
+			point, err := parsePromPoint(value)
+			if err != nil {
+				return nil, err
+			}
+			sums[point.Timestamp] += point.Value
+		}
+	}
+
+	points := make([]PromPoint, 0, len(sums))
+	for ts, value := range sums {
+		points = append(points, PromPoint{Timestamp: ts, Value: value})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Timestamp.Before(points[j].Timestamp) })
+
+	return points, nil
+}
+
+// get chama a API do Prometheus e decodifica o campo data da resposta
+func (s *PrometheusSource) get(ctx context.Context, path string, params url.Values, data interface{}) error {
+	endpoint := strings.TrimSuffix(s.Parameters.Metrics.Prometheus.URL, "/") + path + "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach prometheus at %s: %w", s.Parameters.Metrics.Prometheus.URL, err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Status    string          `json:"status"`
+		Data      json.RawMessage `json:"data"`
+		ErrorType string          `json:"errorType"`
+		Error     string          `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode prometheus response (status %d): %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return fmt.Errorf("prometheus query failed: %s: %s", body.ErrorType, body.Error)
+	}
+
+	return json.Unmarshal(body.Data, data)
+}
+
+// expand substitui $function, $namespace e $platform na consulta. Os valores são
+// escapados para uso em expressões regulares dentro de strings PromQL; namespace vazio vira ".*".
+func (s *PrometheusSource) expand(query, function string) string {
+	if function == "" {
+		function = s.Parameters.Function
+	}
+
+	namespace := ".*"
+	if s.Parameters.Namespace != "" {
+		namespace = promRegexp(s.Parameters.Namespace)
+	}
+
+	return strings.NewReplacer(
+		"$function", promRegexp(function),
+		"$namespace", namespace,
+		"$platform", promRegexp(s.Parameters.Platform),
+	).Replace(query)
+}
+
+// promRegexp escapa o valor para uma expressão regular dentro de uma string PromQL,
+// em que a própria barra invertida precisa de escape
+func promRegexp(value string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(value), `\`, `\\`)
+}
+
+// parsePromPoint interpreta um par [timestamp, "valor"] da API do Prometheus
+func parsePromPoint(pair [2]interface{}) (PromPoint, error) {
+	ts, ok := pair[0].(float64)
+	if !ok {
+		return PromPoint{}, fmt.Errorf("invalid prometheus timestamp: %v", pair[0])
+	}
+	raw, ok := pair[1].(string)
+	if !ok {
+		return PromPoint{}, fmt.Errorf("invalid prometheus value: %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return PromPoint{}, fmt.Errorf("invalid prometheus value: %s", raw)
+	}
+
+	return PromPoint{Timestamp: time.UnixMilli(int64(ts * 1000)), Value: value}, nil
+}
+
+// formatPromTime formata o instante como segundos Unix com fração
+func formatPromTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
+
+// mergeRangeSeries alinha as séries de CPU, memória e réplicas pelos instantes
+func mergeRangeSeries(cpu, memory, pods []PromPoint) []MetricSample {
+	byTime := make(map[time.Time]*MetricSample)
+	sample := func(ts time.Time) *MetricSample {
+		if s, ok := byTime[ts]; ok {
+			return s
+		}
+		s := &MetricSample{Timestamp: ts}
+		byTime[ts] = s
+		return s
+	}
+
+	for _, p := range cpu {
+		sample(p.Timestamp).CPU = p.Value
+	}
+	for _, p := range memory {
+		sample(p.Timestamp).Memory = p.Value
+	}
+	for _, p := range pods {
+		sample(p.Timestamp).Pods = int(p.Value)
+	}
+
+	samples := make([]MetricSample, 0, len(byTime))
+	for _, s := range byTime {
+		samples = append(samples, *s)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Timestamp.Before(samples[j].Timestamp) })
+	return samples
+}
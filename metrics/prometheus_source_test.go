@@ -0,0 +1,278 @@
+package metrics
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// promStub responde às consultas do Prometheus com o corpo associado a cada consulta
+type promStub struct {
+	instant map[string]string // query -> corpo de /api/v1/query
+	ranges  map[string]string // query -> corpo de /api/v1/query_range
+	last    map[string][]string
+}
+
+func (p *promStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	p.last = query
+
+	var bodies map[string]string
+	switch r.URL.Path {
+	case "/api/v1/query":
+		bodies = p.instant
+	case "/api/v1/query_range":
+		bodies = p.ranges
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	body, ok := bodies[query.Get("query")]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		body = `{"status":"error","errorType":"bad_data","error":"unexpected query"}`
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(body))
+}
+
+// newTestPrometheusSource cria a fonte apontando para o servidor de teste, com
+// consultas fixas que não dependem da expansão
+func newTestPrometheusSource(t *testing.T, stub *promStub) *PrometheusSource {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	params := parameters.DefaultParameters()
+	params.Function = "hello"
+	params.Metrics.SampleInterval = "5s"
+	params.Metrics.Prometheus = parameters.PrometheusParameters{
+		URL:         server.URL + "/",
+		CPUUsage:    "cpu",
+		MemoryUsage: "memory",
+		PodCount:    "pods",
+		PodNames:    "names",
+
+		ResourceRequests: "requests",
+		ResourceLimits:   "limits",
+		ContainerInfo:    "containers",
+	}
+	return NewPrometheusSource(params)
+}
+
+func TestPrometheusQuerySumsSeries(t *testing.T) {
+	source := newTestPrometheusSource(t, &promStub{instant: map[string]string{
+		"pods": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"pod":"a"},"value":[1700000000,"2"]},
+			{"metric":{"pod":"b"},"value":[1700000000,"1"]}]}}`,
+	}})
+
+	count, err := source.PodCount(context.Background(), "")
+	if err != nil {
+		t.Fatalf("PodCount: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("PodCount = %d, want 3", count)
+	}
+}
+
+func TestPrometheusErrorStatus(t *testing.T) {
+	source := newTestPrometheusSource(t, &promStub{instant: map[string]string{
+		"pods": `{"status":"error","errorType":"timeout","error":"query timed out"}`,
+	}})
+
+	_, err := source.Query(context.Background(), "pods")
+	if err == nil {
+		t.Fatal("Query with status error returned no error")
+	}
+	if !strings.Contains(err.Error(), "timeout") || !strings.Contains(err.Error(), "query timed out") {
+		t.Errorf("error %q does not carry the prometheus error type and message", err)
+	}
+
+	// Consultas desconhecidas pelo servidor chegam com status HTTP 400 e corpo de erro
+	if _, err := source.Query(context.Background(), "unknown"); err == nil || !strings.Contains(err.Error(), "bad_data") {
+		t.Errorf("Query with HTTP 400 = %v, want bad_data error", err)
+	}
+}
+
+func TestPrometheusQueryRangeSumsPerTimestamp(t *testing.T) {
+	source := newTestPrometheusSource(t, &promStub{ranges: map[string]string{
+		"cpu": `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"pod":"a"},"values":[[1700000010,"100"],[1700000000,"50"]]},
+			{"metric":{"pod":"b"},"values":[[1700000005,"30"],[1700000010,"20"]]}]}}`,
+	}})
+
+	start := time.Unix(1700000000, 0)
+	points, err := source.QueryRange(context.Background(), "cpu", start, start.Add(10*time.Second), 5*time.Second)
+	if err != nil {
+		t.Fatalf("QueryRange: %v", err)
+	}
+
+	want := []PromPoint{
+		{Timestamp: time.Unix(1700000000, 0), Value: 50},
+		{Timestamp: time.Unix(1700000005, 0), Value: 30},
+		{Timestamp: time.Unix(1700000010, 0), Value: 120},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("QueryRange returned %d points, want %d: %v", len(points), len(want), points)
+	}
+	for i := range want {
+		if !points[i].Timestamp.Equal(want[i].Timestamp) || points[i].Value != want[i].Value {
+			t.Errorf("point %d = %v, want %v", i, points[i], want[i])
+		}
+	}
+}
+
+func TestPrometheusCollectWindow(t *testing.T) {
+	stub := &promStub{ranges: map[string]string{
+		"cpu": `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{},"values":[[1700000000,"100"],[1700000005,"300"],[1700000010,"200"]]}]}}`,
+		"memory": `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{},"values":[[1700000000,"1000"],[1700000005,"2000"],[1700000010,"3000"]]}]}}`,
+		"pods": `{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{},"values":[[1700000000,"1"],[1700000005,"4"],[1700000010,"3"]]}]}}`,
+	}}
+	source := newTestPrometheusSource(t, stub)
+
+	window := Window{Start: time.Unix(1700000000, 0), End: time.Unix(1700000010, 0)}
+	collected, err := source.Collect(context.Background(), window)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	if got := stub.last["start"]; len(got) != 1 || got[0] != "1700000000.000" {
+		t.Errorf("start = %v, want 1700000000.000", got)
+	}
+	if got := stub.last["end"]; len(got) != 1 || got[0] != "1700000010.000" {
+		t.Errorf("end = %v, want 1700000010.000", got)
+	}
+	if got := stub.last["step"]; len(got) != 1 || got[0] != "5" {
+		t.Errorf("step = %v, want 5", got)
+	}
+
+	if collected.ClusterCPUUsage != 200 {
+		t.Errorf("ClusterCPUUsage = %g, want 200", collected.ClusterCPUUsage)
+	}
+	if collected.ClusterMemUsage != 2000 {
+		t.Errorf("ClusterMemUsage = %g, want 2000", collected.ClusterMemUsage)
+	}
+	if collected.ScaledPodsDiff != 2 {
+		t.Errorf("ScaledPodsDiff = %d, want 2", collected.ScaledPodsDiff)
+	}
+	if collected.Sampled == nil || len(collected.Sampled.Samples) != 3 {
+		t.Fatalf("Sampled = %+v, want 3 samples", collected.Sampled)
+	}
+	if peak := collected.Sampled.Samples[1]; peak.Pods != 4 || peak.CPU != 300 || peak.Memory != 2000 {
+		t.Errorf("sample at +5s = %+v, want 4 pods, 300 mCores, 2000 bytes", peak)
+	}
+
+	if _, err := source.Collect(context.Background(), Window{}); err == nil {
+		t.Error("Collect without a window returned no error")
+	}
+}
+
+func TestPrometheusPodResources(t *testing.T) {
+	source := newTestPrometheusSource(t, &promStub{instant: map[string]string{
+		// Dois containers no pod a e um no pod b; c não aparece em containers
+		"containers": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"pod":"a","container":"user"},"value":[1700000000,"1"]},
+			{"metric":{"pod":"a","container":"proxy"},"value":[1700000000,"1"]},
+			{"metric":{"pod":"b","container":"user"},"value":[1700000000,"1"]}]}}`,
+		// O pod a declara CPU nos dois containers e memória só no user; o pod b
+		// declara CPU e memória
+		"requests": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"pod":"a","container":"user","resource":"cpu"},"value":[1700000000,"0.25"]},
+			{"metric":{"pod":"a","container":"proxy","resource":"cpu"},"value":[1700000000,"0.05"]},
+			{"metric":{"pod":"a","container":"user","resource":"memory"},"value":[1700000000,"134217728"]},
+			{"metric":{"pod":"b","container":"user","resource":"cpu"},"value":[1700000000,"0.1"]},
+			{"metric":{"pod":"b","container":"user","resource":"memory"},"value":[1700000000,"67108864"]},
+			{"metric":{"pod":"c","container":"user","resource":"cpu"},"value":[1700000000,"4"]}]}}`,
+		"limits": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"pod":"a","container":"user","resource":"memory"},"value":[1700000000,"268435456"]}]}}`,
+	}})
+
+	resources, err := source.PodResources(context.Background(), "")
+	if err != nil {
+		t.Fatalf("PodResources: %v", err)
+	}
+
+	want := PodResources{
+		Pods:          2,
+		CPURequest:    200,      // média de 300 e 100 millicores
+		MemoryRequest: 67108864, // só o pod b declara memória em todos os containers
+		MemoryLimit:   0,        // limit parcial no pod a
+	}
+	if math.Abs(resources.CPURequest-want.CPURequest) > 1e-9 {
+		t.Errorf("CPURequest = %g, want %g", resources.CPURequest, want.CPURequest)
+	}
+	resources.CPURequest = want.CPURequest
+	if resources != want {
+		t.Errorf("PodResources = %+v, want %+v", resources, want)
+	}
+}
+
+func TestPrometheusExpand(t *testing.T) {
+	tests := []struct {
+		name      string
+		function  string
+		namespace string
+		platform  string
+		query     string
+		want      string
+	}{
+		{
+			name:     "namespace vazio considera todos",
+			function: "hello",
+			platform: "knative",
+			query:    `up{namespace=~"$namespace",pod=~"$function-.*",platform="$platform"}`,
+			want:     `up{namespace=~".*",pod=~"hello-.*",platform="knative"}`,
+		},
+		{
+			name:      "metacaracteres de regex",
+			function:  "fn.v1+beta",
+			namespace: "team(a)",
+			platform:  "knative",
+			query:     `up{namespace=~"$namespace",pod=~"$function-.*"}`,
+			want:      `up{namespace=~"team\\(a\\)",pod=~"fn\\.v1\\+beta-.*"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := parameters.DefaultParameters()
+			params.Function = tt.function
+			params.Namespace = tt.namespace
+			params.Platform = tt.platform
+			source := NewPrometheusSource(params)
+
+			if got := source.expand(tt.query, ""); got != tt.want {
+				t.Errorf("expand = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromRegexp(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"hello", "hello"},
+		{"a.b", `a\\.b`},
+		{`back\slash`, `back\\\\slash`},
+		{"x|y*", `x\\|y\\*`},
+	}
+
+	for _, tt := range tests {
+		if got := promRegexp(tt.value); got != tt.want {
+			t.Errorf("promRegexp(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
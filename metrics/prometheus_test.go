@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseExpositionLabelEscapes(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"aspas", `m{path="say \"hi\""} 1`, `say "hi"`},
+		{"barra invertida", `m{path="C:\\tmp\\x"} 1`, `C:\tmp\x`},
+		{"quebra de linha", `m{path="a\nb"} 1`, "a\nb"},
+		{"vírgula e chave no valor", `m{path="a,b}c"} 1`, "a,b}c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			families, err := ParseExposition(tt.line, false)
+			if err != nil {
+				t.Fatalf("ParseExposition: %v", err)
+			}
+			samples := SelectSeries(families, "m", nil)
+			if len(samples) != 1 {
+				t.Fatalf("got %d samples, want 1", len(samples))
+			}
+			if got := samples[0].Labels["path"]; got != tt.want {
+				t.Errorf("path = %q, want %q", got, tt.want)
+			}
+			if samples[0].Value != 1 {
+				t.Errorf("value = %g, want 1", samples[0].Value)
+			}
+		})
+	}
+}
+
+func TestParseExpositionTimestamps(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		openMetrics bool
+		want        time.Time
+	}{
+		{"prometheus em milissegundos", "m 1 1700000000500", false, time.Unix(1700000000, 500e6)},
+		{"openmetrics em segundos", "m 1 1700000000.5", true, time.Unix(1700000000, 500e6)},
+		{"openmetrics em segundos inteiros", "m 1 1700000000", true, time.Unix(1700000000, 0)},
+		{"sem timestamp", "m 1", false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			families, err := ParseExposition(tt.line, tt.openMetrics)
+			if err != nil {
+				t.Fatalf("ParseExposition: %v", err)
+			}
+			got := families[0].Samples[0].Timestamp
+			if !got.Equal(tt.want) {
+				t.Errorf("timestamp = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpositionGroupsFamilies(t *testing.T) {
+	text := `# HELP req_seconds Request latency.
+# TYPE req_seconds histogram
+req_seconds_bucket{le="0.1"} 3
+req_seconds_bucket{le="1"} 5
+req_seconds_bucket{le="+Inf"} 6
+req_seconds_sum 2.5
+req_seconds_count 6
+# TYPE rpc summary
+rpc{quantile="0.5"} 0.2
+rpc{quantile="0.99"} 0.9
+rpc_sum 10
+rpc_count 40
+# TYPE jobs_total counter
+jobs_total 7
+other_count 1
+`
+	families, err := ParseExposition(text, false)
+	if err != nil {
+		t.Fatalf("ParseExposition: %v", err)
+	}
+
+	tests := []struct {
+		family  string
+		kind    string
+		samples int
+	}{
+		{"req_seconds", "histogram", 5},
+		{"rpc", "summary", 4},
+		{"jobs_total", "counter", 1},
+		{"other_count", "untyped", 1}, // sufixo sem família declarada
+	}
+	if len(families) != len(tests) {
+		t.Fatalf("got %d families, want %d", len(families), len(tests))
+	}
+	for i, tt := range tests {
+		f := families[i]
+		if f.Name != tt.family || f.Type != tt.kind || len(f.Samples) != tt.samples {
+			t.Errorf("family %d = %s (%s, %d samples), want %s (%s, %d samples)",
+				i, f.Name, f.Type, len(f.Samples), tt.family, tt.kind, tt.samples)
+		}
+	}
+
+	hist := FindFamily(families, "req_seconds")
+	if hist.Help != "Request latency." {
+		t.Errorf("help = %q", hist.Help)
+	}
+	buckets := hist.Buckets(nil)
+	if len(buckets) != 3 || buckets[0].UpperBound != 0.1 || buckets[2].Count != 6 {
+		t.Errorf("buckets = %v", buckets)
+	}
+
+	quantiles := FindFamily(families, "rpc").Quantiles(nil)
+	if quantiles[0.99] != 0.9 || len(quantiles) != 2 {
+		t.Errorf("quantiles = %v", quantiles)
+	}
+}
+
+func TestParseExpositionExemplars(t *testing.T) {
+	text := `# TYPE req_seconds histogram
+req_seconds_bucket{le="0.5"} 4 # {trace_id="abc"} 0.31 1700000000.1
+req_seconds_bucket{le="+Inf"} 5 1700000001 # {trace_id="def"} 2.0
+# EOF
+`
+	families, err := ParseExposition(text, true)
+	if err != nil {
+		t.Fatalf("ParseExposition: %v", err)
+	}
+
+	samples := SelectSeries(families, "req_seconds_bucket", nil)
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples, want 2", len(samples))
+	}
+	if samples[0].Value != 4 || !samples[0].Timestamp.IsZero() {
+		t.Errorf("first sample = %+v, want value 4 without timestamp", samples[0])
+	}
+	if _, ok := samples[0].Labels["trace_id"]; ok {
+		t.Error("exemplar labels leaked into the series")
+	}
+	if samples[1].Value != 5 || !samples[1].Timestamp.Equal(time.Unix(1700000001, 0)) {
+		t.Errorf("second sample = %+v, want value 5 at 1700000001", samples[1])
+	}
+}
+
+func TestParseExpositionStrict(t *testing.T) {
+	text := "good 1\nbad{le=\"1\" 2\nalso_good 3\n"
+	_, err := ParseExposition(text, false)
+	if err == nil {
+		t.Fatal("malformed line did not fail the scrape")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error %q does not name the malformed line", err)
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector Selector
+		labels   map[string]string
+		want     bool
+	}{
+		{"valores iguais", Selector{"function": "hello"}, map[string]string{"function": "hello"}, true},
+		{"valor diferente", Selector{"function": "hello"}, map[string]string{"function": "other"}, false},
+		{"label ausente não exclui", Selector{"function": "hello", "platform": "knative"}, map[string]string{"function": "hello"}, true},
+		{"valor vazio não filtra", Selector{"function": ""}, map[string]string{"function": "other"}, true},
+		{"seletor nulo", nil, map[string]string{"function": "other"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.selector.Matches(tt.labels); got != tt.want {
+				t.Errorf("Matches = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
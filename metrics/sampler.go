@@ -0,0 +1,202 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+)
+
+// MetricSample é uma leitura das métricas do cluster em um instante
+type MetricSample struct {
+	Timestamp time.Time
+	CPU       float64 // millicores somados dos pods da função
+	Memory    float64 // bytes somados dos pods da função
+	Pods      int     // réplicas da função
+}
+
+// SampleFunc lê as métricas atuais do cluster
+type SampleFunc func(ctx context.Context) (MetricSample, error)
+
+// Sampler lê as métricas do cluster em segundo plano, a cada Interval, entre Start e Stop
+type Sampler struct {
+	Interval time.Duration
+
+	sample  SampleFunc
+	mu      sync.Mutex
+	samples []MetricSample
+	errors  int
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewSampler cria uma nova instância do Sampler
+func NewSampler(interval time.Duration, sample SampleFunc) *Sampler {
+	return &Sampler{
+		Interval: interval,
+		sample:   sample,
+	}
+}
+
+// Start faz a primeira leitura imediatamente e inicia a goroutine de amostragem
+func (s *Sampler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	s.record(ctx)
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.record(ctx)
+			}
+		}
+	}()
+}
+
+// Stop encerra a goroutine, faz uma última leitura e retorna a série completa
+func (s *Sampler) Stop(ctx context.Context) []MetricSample {
+	s.cancel()
+	<-s.done
+
+	s.record(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]MetricSample(nil), s.samples...)
+}
+
+// Samples retorna as leituras feitas até agora, sem interromper a amostragem
+func (s *Sampler) Samples() []MetricSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]MetricSample(nil), s.samples...)
+}
+
+// Errors retorna o número de leituras que falharam
+func (s *Sampler) Errors() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.errors
+}
+
+// record faz uma leitura; falhas pontuais são contadas e não interrompem a amostragem
+func (s *Sampler) record(ctx context.Context) {
+	sample, err := s.sample(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.errors++
+		return
+	}
+	if sample.Timestamp.IsZero() {
+		sample.Timestamp = time.Now()
+	}
+	s.samples = append(s.samples, sample)
+}
+
+// SeriesSummary resume uma série amostrada
+type SeriesSummary struct {
+	Min float64
+	Avg float64
+	P95 float64
+	Max float64
+}
+
+// SampledMetrics guarda a série de leituras feitas durante a carga e o resumo de cada métrica
+type SampledMetrics struct {
+	Interval time.Duration
+	Samples  []MetricSample
+	Errors   int // leituras que falharam
+
+	CPU    SeriesSummary // millicores
+	Memory SeriesSummary // bytes
+	Pods   SeriesSummary
+
+	// Leituras feitas depois da carga, enquanto as réplicas voltavam; usadas apenas
+	// na linha do tempo do autoscaling e fora dos resumos
+	ScaleDown []MetricSample
+}
+
+// NewSampledMetrics calcula mínimo, média, p95 e máximo de cada métrica da série
+func NewSampledMetrics(interval time.Duration, samples []MetricSample, errors int) *SampledMetrics {
+	m := &SampledMetrics{
+		Interval: interval,
+		Samples:  samples,
+		Errors:   errors,
+	}
+
+	cpu := make([]float64, len(samples))
+	memory := make([]float64, len(samples))
+	pods := make([]float64, len(samples))
+	for i, s := range samples {
+		cpu[i], memory[i], pods[i] = s.CPU, s.Memory, float64(s.Pods)
+	}
+
+	m.CPU = summarizeSeries(cpu)
+	m.Memory = summarizeSeries(memory)
+	m.Pods = summarizeSeries(pods)
+
+	return m
+}
+
+// Slice resume apenas as leituras feitas dentro da janela
+func (m *SampledMetrics) Slice(window Window) *SampledMetrics {
+	var samples []MetricSample
+	for _, sample := range m.Samples {
+		if !sample.Timestamp.Before(window.Start) && !sample.Timestamp.After(window.End) {
+			samples = append(samples, sample)
+		}
+	}
+	return NewSampledMetrics(m.Interval, samples, 0)
+}
+
+// SplitScaleDown resume apenas as leituras feitas até after e guarda as seguintes
+// em ScaleDown
+func (m *SampledMetrics) SplitScaleDown(after time.Time) *SampledMetrics {
+	var load, scaleDown []MetricSample
+	for _, sample := range m.Samples {
+		if sample.Timestamp.After(after) {
+			scaleDown = append(scaleDown, sample)
+		} else {
+			load = append(load, sample)
+		}
+	}
+	split := NewSampledMetrics(m.Interval, load, m.Errors)
+	split.ScaleDown = scaleDown
+	return split
+}
+
+// summarizeSeries calcula o resumo de uma série de valores
+func summarizeSeries(values []float64) SeriesSummary {
+	if len(values) == 0 {
+		return SeriesSummary{}
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+
+	return SeriesSummary{
+		Min: sorted[0],
+		Avg: sum / float64(len(sorted)),
+		P95: heyexec.Percentile(sorted, 95),
+		Max: sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,288 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+)
+
+// PodLister é implementado pelas fontes de métricas que conhecem os nomes dos
+// pods da função; sem ele os snapshots trazem apenas as contagens
+type PodLister interface {
+	PodNames(ctx context.Context, function string) ([]string, error)
+}
+
+// CollectionWaiter é implementado pelas fontes que publicam leituras periódicas,
+// como o exporter; o snapshot final de cada execução espera uma leitura iniciada
+// depois do fim da execução, para não refletir o estado de antes dele
+type CollectionWaiter interface {
+	WaitForCollection(ctx context.Context, after time.Time) error
+}
+
+// ClusterSnapshot é o estado da função no cluster em um instante
+type ClusterSnapshot struct {
+	Timestamp time.Time
+	Pods      int
+	PodNames  []string // vazio quando a fonte não lista os pods
+	CPU       float64  // Millicores
+	Memory    float64  // Bytes
+
+	// Especificação das réplicas, nil quando a fonte não a lê ou não há réplicas
+	Resources *PodResources
+}
+
+// TakeSnapshot lê o estado atual da função pela fonte de métricas
+func TakeSnapshot(ctx context.Context, source MetricsSource, function string) (ClusterSnapshot, error) {
+	sample, err := source.Sample(ctx, function)
+	if err != nil {
+		return ClusterSnapshot{}, err
+	}
+
+	snapshot := ClusterSnapshot{
+		Timestamp: sample.Timestamp,
+		Pods:      sample.Pods,
+		CPU:       sample.CPU,
+		Memory:    sample.Memory,
+	}
+
+	if lister, ok := source.(PodLister); ok {
+		names, err := lister.PodNames(ctx, function)
+		if err != nil {
+			return snapshot, err
+		}
+		sort.Strings(names)
+		snapshot.PodNames = names
+	}
+
+	if reader, ok := source.(ResourceReader); ok {
+		resources, err := reader.PodResources(ctx, function)
+		if err != nil {
+			return snapshot, err
+		}
+		if resources.Pods > 0 {
+			snapshot.Resources = &resources
+		}
+	}
+
+	return snapshot, nil
+}
+
+// SnapshotDelta compara o estado da função antes e depois de uma execução
+type SnapshotDelta struct {
+	Before ClusterSnapshot
+	After  ClusterSnapshot
+
+	PodDelta    int
+	NewPods     []string // pods presentes apenas depois da execução
+	RemovedPods []string // pods presentes apenas antes da execução
+	CPUDelta    float64  // Millicores
+	MemoryDelta float64  // Bytes
+
+	Error string // falha em um dos snapshots, vazio em caso de sucesso
+}
+
+// NewSnapshotDelta calcula as variações entre os snapshots de antes e depois
+func NewSnapshotDelta(before, after ClusterSnapshot) *SnapshotDelta {
+	delta := &SnapshotDelta{
+		Before:      before,
+		After:       after,
+		PodDelta:    after.Pods - before.Pods,
+		CPUDelta:    after.CPU - before.CPU,
+		MemoryDelta: after.Memory - before.Memory,
+	}
+
+	delta.NewPods = podsDifference(after.PodNames, before.PodNames)
+	delta.RemovedPods = podsDifference(before.PodNames, after.PodNames)
+
+	return delta
+}
+
+// scenarioPodDelta calcula a variação de réplicas entre o snapshot de antes da
+// primeira execução e o de depois da última; ok é falso sem os dois snapshots
+func scenarioPodDelta(executions []ExecutionMetrics) (diff int, ok bool) {
+	if len(executions) == 0 {
+		return 0, false
+	}
+	first, last := executions[0].Snapshot, executions[len(executions)-1].Snapshot
+	if first == nil || last == nil || first.Error != "" || last.Error != "" {
+		return 0, false
+	}
+	return last.After.Pods - first.Before.Pods, true
+}
+
+// podsDifference retorna os pods de a que não estão em b, na ordem de a
+func podsDifference(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, name := range b {
+		present[name] = true
+	}
+
+	var diff []string
+	for _, name := range a {
+		if !present[name] {
+			diff = append(diff, name)
+		}
+	}
+	return diff
+}
+
+// ClusterMetrics são as métricas do cluster coletadas para a janela de uma execução
+type ClusterMetrics struct {
+	Window         Window
+	ScaledPodsDiff int
+
+	// Médias na janela, da série da fonte ou das leituras contínuas; sem nenhuma
+	// leitura dentro da janela, o valor lido pela fonte ao fim da execução
+	ClusterCPUUsage float64 // Millicores
+	ClusterMemUsage float64 // Bytes
+
+	PodStartedAt map[string]float64
+	Startup      *StartupBreakdown
+
+	// Série da janela, quando a fonte a fornece ou pelas leituras contínuas
+	Sampled *SampledMetrics
+
+	Error string // falha na coleta, vazio em caso de sucesso
+}
+
+// NewClusterMetrics extrai as métricas do cluster de uma coleta sobre a janela
+func NewClusterMetrics(window Window, collected ConsolidatedMetrics) *ClusterMetrics {
+	return &ClusterMetrics{
+		Window:          window,
+		ScaledPodsDiff:  collected.ScaledPodsDiff,
+		ClusterCPUUsage: collected.ClusterCPUUsage,
+		ClusterMemUsage: collected.ClusterMemUsage,
+		PodStartedAt:    collected.PodStartedAt,
+		Startup:         collected.Startup,
+		Sampled:         collected.Sampled,
+	}
+}
+
+// ExecutionTracker tira um snapshot da função logo antes e logo depois de cada
+// execução do gerador de carga e, ao fim de cada uma, coleta as métricas do
+// cluster da sua janela. Fontes sem leituras instantâneas não geram snapshots.
+// Implementa heyexec.ExecutionObserver.
+type ExecutionTracker struct {
+	Source   MetricsSource
+	Function string
+
+	mu       sync.Mutex
+	before   map[int]ClusterSnapshot
+	failures map[int]error
+	deltas   map[int]*SnapshotDelta
+	clusters map[int]*ClusterMetrics
+}
+
+// NewExecutionTracker cria uma nova instância do ExecutionTracker
+func NewExecutionTracker(source MetricsSource, function string) *ExecutionTracker {
+	return &ExecutionTracker{
+		Source:   source,
+		Function: function,
+		before:   make(map[int]ClusterSnapshot),
+		failures: make(map[int]error),
+		deltas:   make(map[int]*SnapshotDelta),
+		clusters: make(map[int]*ClusterMetrics),
+	}
+}
+
+// BeforeExecution registra o snapshot de referência da execução
+func (t *ExecutionTracker) BeforeExecution(ctx context.Context, index int) {
+	snapshot, err := TakeSnapshot(ctx, t.Source, t.Function)
+	if errors.Is(err, ErrNotSupported) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.failures[index] = fmt.Errorf("baseline snapshot: %w", err)
+		return
+	}
+	t.before[index] = snapshot
+}
+
+// AfterExecution registra o snapshot final da execução, calcula as variações e
+// coleta as métricas do cluster entre o início e o fim da execução
+func (t *ExecutionTracker) AfterExecution(ctx context.Context, index int, result *heyexec.RunResult) {
+	if waiter, ok := t.Source.(CollectionWaiter); ok && result != nil && !result.EndTime.IsZero() {
+		if err := waiter.WaitForCollection(ctx, result.EndTime); err != nil && !errors.Is(err, ErrNotSupported) {
+			log.Printf("Aviso: snapshot final da execução %d sem leitura posterior ao fim da carga: %v", index, err)
+		}
+	}
+
+	snapshot, err := TakeSnapshot(ctx, t.Source, t.Function)
+	t.collectWindow(ctx, index, result)
+	if errors.Is(err, ErrNotSupported) {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	before, ok := t.before[index]
+	switch {
+	case !ok:
+		reason := "baseline snapshot missing"
+		if failure := t.failures[index]; failure != nil {
+			reason = failure.Error()
+		}
+		t.deltas[index] = &SnapshotDelta{After: snapshot, Error: reason}
+	case err != nil:
+		t.deltas[index] = &SnapshotDelta{Before: before, Error: fmt.Sprintf("final snapshot: %v", err)}
+	default:
+		t.deltas[index] = NewSnapshotDelta(before, snapshot)
+	}
+}
+
+// collectWindow coleta as métricas do cluster na janela da execução
+func (t *ExecutionTracker) collectWindow(ctx context.Context, index int, result *heyexec.RunResult) {
+	if _, none := t.Source.(NoneSource); none || result == nil {
+		return
+	}
+
+	window := Window{Start: result.StartTime, End: result.EndTime}
+	if !window.Valid() {
+		return
+	}
+
+	collected, err := t.Source.Collect(ctx, window)
+	cluster := NewClusterMetrics(window, collected)
+	if err != nil {
+		cluster.Error = err.Error()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clusters[index] = cluster
+}
+
+// Clusters retorna as métricas do cluster de cada execução, indexadas pela execução (começando em 1)
+func (t *ExecutionTracker) Clusters() map[int]*ClusterMetrics {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clusters := make(map[int]*ClusterMetrics, len(t.clusters))
+	for index, cluster := range t.clusters {
+		clusters[index] = cluster
+	}
+	return clusters
+}
+
+// Snapshots retorna as variações registradas, indexadas pela execução (começando em 1)
+func (t *ExecutionTracker) Snapshots() map[int]*SnapshotDelta {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshots := make(map[int]*SnapshotDelta, len(t.deltas))
+	for index, delta := range t.deltas {
+		snapshots[index] = delta
+	}
+	return snapshots
+}
@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
+	"github.com/mariaisadora-github/FaaSKubeBench/parameters"
+)
+
+// ErrNotSupported indica que a fonte de métricas não oferece a leitura pedida
+var ErrNotSupported = errors.New("not supported by the metrics source")
+
+// ErrNotFound indica que a fonte não tem séries da função, como um nome de função
+// errado ou uma função que o exporter ainda não observou com pods. As esperas por
+// escala para zero a tratam como zero pods; as demais leituras, como desconhecida.
+var ErrNotFound = errors.New("no series found for the function")
+
+// Window é o intervalo de tempo da carga sobre o qual as métricas são coletadas
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Valid indica se a janela tem início e termina depois dele
+func (w Window) Valid() bool {
+	return !w.Start.IsZero() && w.End.After(w.Start)
+}
+
+// Duration retorna a duração da janela
+func (w Window) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// RunWindow retorna a janela do início da primeira execução ao fim da última
+func RunWindow(runs []*heyexec.RunResult) (Window, bool) {
+	var window Window
+	for _, run := range runs {
+		if run == nil || run.StartTime.IsZero() {
+			continue
+		}
+		if window.Start.IsZero() || run.StartTime.Before(window.Start) {
+			window.Start = run.StartTime
+		}
+		if run.EndTime.After(window.End) {
+			window.End = run.EndTime
+		}
+	}
+	return window, window.Valid()
+}
+
+// MetricsSource fornece as métricas do cluster para um cenário, independente de
+// onde elas vêm. Fontes que só conhecem os valores atuais ignoram a janela em
+// Collect; fontes sem leituras instantâneas retornam ErrNotSupported em PodCount
+// e Sample.
+type MetricsSource interface {
+	// Collect retorna as métricas do cluster referentes à janela da carga
+	Collect(ctx context.Context, window Window) (ConsolidatedMetrics, error)
+
+	// PodCount retorna o número atual de réplicas da função
+	PodCount(ctx context.Context, function string) (int, error)
+
+	// Sample retorna os valores atuais de CPU, memória e réplicas da função
+	Sample(ctx context.Context, function string) (MetricSample, error)
+}
+
+// SourceFactory cria a fonte de métricas de um cenário a partir dos seus parâmetros
+type SourceFactory func(ctx context.Context, params *parameters.BenchmarkParameters) (MetricsSource, error)
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]SourceFactory)
+)
+
+// RegisterSource registra uma fonte de métricas com o nome usado em metrics.source.
+// Pacotes com fontes próprias chamam RegisterSource em init.
+func RegisterSource(name string, factory SourceFactory) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if factory == nil {
+		panic("metrics: RegisterSource factory is nil")
+	}
+	if _, dup := sources[name]; dup {
+		panic("metrics: RegisterSource called twice for source " + name)
+	}
+	sources[name] = factory
+}
+
+// SourceNames retorna os nomes das fontes registradas, em ordem alfabética
+func SourceNames() []string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSource cria a fonte de métricas configurada em metrics.source
+func NewSource(ctx context.Context, params *parameters.BenchmarkParameters) (MetricsSource, error) {
+	sourcesMu.RLock()
+	factory, ok := sources[params.Metrics.Source]
+	sourcesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported metrics source: %s. Supported sources: %s",
+			params.Metrics.Source, strings.Join(SourceNames(), ", "))
+	}
+	return factory(ctx, params)
+}
+
+// NoneSource não coleta métricas do cluster; o resultado traz apenas as métricas
+// do gerador de carga
+type NoneSource struct{}
+
+// Collect retorna métricas do cluster vazias
+func (NoneSource) Collect(ctx context.Context, window Window) (ConsolidatedMetrics, error) {
+	return ConsolidatedMetrics{PodStartedAt: make(map[string]float64)}, nil
+}
+
+// PodCount não é suportado sem uma fonte de métricas
+func (NoneSource) PodCount(ctx context.Context, function string) (int, error) {
+	return 0, ErrNotSupported
+}
+
+// Sample não é suportado sem uma fonte de métricas
+func (NoneSource) Sample(ctx context.Context, function string) (MetricSample, error) {
+	return MetricSample{}, ErrNotSupported
+}
+
+func init() {
+	RegisterSource(parameters.MetricsExporter, newExporterSource)
+	RegisterSource(parameters.MetricsPrometheus, func(ctx context.Context, params *parameters.BenchmarkParameters) (MetricsSource, error) {
+		return NewPrometheusSource(params), nil
+	})
+	RegisterSource(parameters.MetricsNone, func(ctx context.Context, params *parameters.BenchmarkParameters) (MetricsSource, error) {
+		return NoneSource{}, nil
+	})
+}
+
+// newExporterSource cria a fonte do exporter filtrada pela função do cenário e
+// avisa sobre as métricas associadas que o exporter não publica, pois esses
+// campos ficariam zerados
+func newExporterSource(ctx context.Context, params *parameters.BenchmarkParameters) (MetricsSource, error) {
+	source := NewExporterSource(params.Metrics.ExporterURL)
+	source.SetSelector(Selector{
+		"function":  params.Function,
+		"namespace": params.Namespace,
+		"platform":  params.Platform,
+	})
+	source.SetMapping(params.Metrics.Mapping)
+	interval, _ := time.ParseDuration(params.Metrics.SampleInterval)
+	source.SetCollectionInterval(interval)
+
+	warnings, err := source.CheckMapping(ctx)
+	if err != nil {
+		log.Printf("Aviso: não foi possível verificar as métricas do exporter: %v", err)
+	}
+	for _, warning := range warnings {
+		log.Printf("Aviso: %s", warning)
+	}
+
+	return source, nil
+}
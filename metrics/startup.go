@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// PodStartup decompõe a inicialização de um pod novo nas fases observadas nas
+// condições do pod e nos eventos do cluster. Instantes desconhecidos ficam zerados
+// e as fases que dependem deles não entram nos agregados.
+type PodStartup struct {
+	Pod string
+
+	Created   time.Time // criação do objeto Pod
+	Scheduled time.Time // condição PodScheduled
+	Pulled    time.Time // último evento Pulled dos containers
+	Started   time.Time // último container em execução
+	Ready     time.Time // condição Ready
+
+	ImageCached bool   // todas as imagens já estavam no nó
+	Error       string // falha ao ler os eventos do pod
+}
+
+// Scheduling é o tempo da criação até o pod ser atribuído a um nó
+func (p PodStartup) Scheduling() time.Duration {
+	return phase(p.Created, p.Scheduled)
+}
+
+// ImagePull é o tempo do agendamento até as imagens estarem disponíveis no nó
+func (p PodStartup) ImagePull() time.Duration {
+	return phase(p.Scheduled, p.Pulled)
+}
+
+// ContainerStart é o tempo das imagens disponíveis até os containers em execução
+func (p PodStartup) ContainerStart() time.Duration {
+	return phase(p.Pulled, p.Started)
+}
+
+// Readiness é o tempo dos containers em execução até o pod ficar Ready
+func (p PodStartup) Readiness() time.Duration {
+	return phase(p.Started, p.Ready)
+}
+
+// Total é o tempo da criação até o pod ficar Ready
+func (p PodStartup) Total() time.Duration {
+	return phase(p.Created, p.Ready)
+}
+
+// phase retorna a duração entre dois instantes, ou zero quando algum é desconhecido.
+// Os eventos têm resolução de segundos, então fases curtas podem sair negativas e
+// são limitadas a zero.
+func phase(from, to time.Time) time.Duration {
+	if from.IsZero() || to.IsZero() {
+		return 0
+	}
+	return max(to.Sub(from), 0)
+}
+
+// StartupBreakdown reúne a decomposição de cada pod novo e os agregados por fase, em segundos
+type StartupBreakdown struct {
+	Pods []PodStartup
+
+	Scheduling     Stats
+	ImagePull      Stats
+	ContainerStart Stats
+	Readiness      Stats
+	Total          Stats
+
+	CachedImages int // pods cujas imagens já estavam no nó
+}
+
+// NewStartupBreakdown calcula os agregados de cada fase sobre os pods em que os
+// dois instantes da fase são conhecidos. Retorna nil sem pods.
+func NewStartupBreakdown(pods []PodStartup) *StartupBreakdown {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Created.Before(pods[j].Created) })
+
+	var scheduling, imagePull, containerStart, readiness, total []float64
+	b := &StartupBreakdown{Pods: pods}
+	for _, p := range pods {
+		scheduling = appendPhase(scheduling, p.Created, p.Scheduled)
+		imagePull = appendPhase(imagePull, p.Scheduled, p.Pulled)
+		containerStart = appendPhase(containerStart, p.Pulled, p.Started)
+		readiness = appendPhase(readiness, p.Started, p.Ready)
+		total = appendPhase(total, p.Created, p.Ready)
+		if p.ImageCached {
+			b.CachedImages++
+		}
+	}
+
+	b.Scheduling = NewStats(scheduling)
+	b.ImagePull = NewStats(imagePull)
+	b.ContainerStart = NewStats(containerStart)
+	b.Readiness = NewStats(readiness)
+	b.Total = NewStats(total)
+
+	return b
+}
+
+// appendPhase acrescenta a duração da fase em segundos quando os dois instantes são conhecidos
+func appendPhase(values []float64, from, to time.Time) []float64 {
+	if from.IsZero() || to.IsZero() {
+		return values
+	}
+	return append(values, phase(from, to).Seconds())
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats resume a distribuição de uma métrica entre várias execuções
+type Stats struct {
+	N        int
+	Mean     float64
+	Median   float64
+	StdDev   float64 // Desvio padrão amostral (n-1)
+	Min      float64
+	Max      float64
+	CI95Low  float64 // Limite inferior do intervalo de confiança de 95% da média
+	CI95High float64 // Limite superior do intervalo de confiança de 95% da média
+}
+
+// tCritical95 contém os valores críticos bicaudais da distribuição t de Student
+// para 95% de confiança, indexados por graus de liberdade (1 a 30)
+var tCritical95 = []float64{
+	0, 12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// NewStats calcula média, mediana, desvio padrão, mínimo, máximo e o intervalo
+// de confiança de 95% da média para os valores informados
+func NewStats(values []float64) Stats {
+	stats := Stats{N: len(values)}
+	if len(values) == 0 {
+		return stats
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Mean = sum / float64(len(sorted))
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		stats.Median = (sorted[mid-1] + sorted[mid]) / 2
+	} else {
+		stats.Median = sorted[mid]
+	}
+
+	// Com uma única amostra não há variância nem intervalo de confiança
+	if len(sorted) < 2 {
+		stats.CI95Low = stats.Mean
+		stats.CI95High = stats.Mean
+		return stats
+	}
+
+	sqDiff := 0.0
+	for _, v := range sorted {
+		sqDiff += (v - stats.Mean) * (v - stats.Mean)
+	}
+	stats.StdDev = math.Sqrt(sqDiff / float64(len(sorted)-1))
+
+	margin := tCritical(len(sorted)-1) * stats.StdDev / math.Sqrt(float64(len(sorted)))
+	stats.CI95Low = stats.Mean - margin
+	stats.CI95High = stats.Mean + margin
+
+	return stats
+}
+
+// tCritical retorna o valor crítico t para 95% de confiança; acima de 30 graus
+// de liberdade usa a aproximação normal
+func tCritical(df int) float64 {
+	if df < len(tCritical95) {
+		return tCritical95[df]
+	}
+	return 1.96
+}
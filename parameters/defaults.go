@@ -8,6 +8,61 @@ func DefaultParameters() *BenchmarkParameters {
 		Execution:   1,
 		Platform:    "knative",
 		Workload:    "cpu",
+		Engine:      EngineHey,
+		Mode:        ModeLoad,
+		Percentiles: []float64{50, 90, 95, 99, 99.9},
+		Arrival: ArrivalParameters{
+			Mode:        ArrivalClosed,
+			MaxInFlight: 10000,
+		},
+		Metrics: MetricsParameters{
+			Source:           MetricsExporter,
+			ExporterURL:      "http://localhost:8000/metrics",
+			SampleInterval:   "5s",
+			ScaleDownTimeout: "0s",
+			// Nomes publicados pelo exporter em metrics/exporter.py
+			Mapping: MetricMapping{
+				CPUUsage:     MetricBinding{Metric: "serverless_pod_cpu_usage_millicores", Aggregation: AggregationSum},
+				MemoryUsage:  MetricBinding{Metric: "serverless_pod_memory_usage_bytes", Aggregation: AggregationSum},
+				PodCount:     MetricBinding{Metric: "serverless_pod_count", Aggregation: AggregationSum},
+				ScaledPods:   MetricBinding{Metric: "serverless_pod_scaled_difference", Aggregation: AggregationSum},
+				BootDuration: MetricBinding{Metric: "serverless_pod_boot_duration_seconds", Aggregation: AggregationAvg},
+				StartedAt:    MetricBinding{Aggregation: AggregationMax},
+			},
+			// Consultas sobre as métricas do cAdvisor e do kube-state-metrics; os pods
+			// da função são identificados pelo prefixo do nome
+			Prometheus: PrometheusParameters{
+				URL:              "http://localhost:9090",
+				CPUUsage:         `sum(rate(container_cpu_usage_seconds_total{namespace=~"$namespace",pod=~"$function-.*",container!="",container!="POD"}[1m])) * 1000`,
+				MemoryUsage:      `sum(container_memory_working_set_bytes{namespace=~"$namespace",pod=~"$function-.*",container!="",container!="POD"})`,
+				PodCount:         `count(kube_pod_info{namespace=~"$namespace",pod=~"$function-.*"})`,
+				PodNames:         `kube_pod_info{namespace=~"$namespace",pod=~"$function-.*"}`,
+				ResourceRequests: `kube_pod_container_resource_requests{namespace=~"$namespace",pod=~"$function-.*",resource=~"cpu|memory"}`,
+				ResourceLimits:   `kube_pod_container_resource_limits{namespace=~"$namespace",pod=~"$function-.*",resource=~"cpu|memory"}`,
+				ContainerInfo:    `kube_pod_container_info{namespace=~"$namespace",pod=~"$function-.*"}`,
+			},
+		},
+		Cooldown: CooldownParameters{
+			Policy:       CooldownFixed,
+			Timeout:      "10m",
+			PollInterval: "5s",
+			CPUThreshold: 10,
+		},
+		Cost: CostParameters{
+			Currency: "USD",
+		},
+		ColdStart: ColdStartParameters{
+			Iterations:         10,
+			ScaleToZeroTimeout: "10m",
+			PollInterval:       "5s",
+		},
+		KeepAlive: KeepAliveParameters{
+			Strategy:   KeepAliveDoubling,
+			MinGap:     "15s",
+			MaxGap:     "20m",
+			Resolution: "15s",
+			ColdFactor: 3,
+		},
 		Hey: HeyParameters{
 			// Não definir Method e Timeout como padrão para evitar aparecer na linha de comando
 			// O hey usará seus próprios padrões (GET e timeout padrão)
@@ -40,6 +95,142 @@ func ApplyDefaults(parameters *BenchmarkParameters) {
 		parameters.Workload = defaults.Workload
 	}
 
+	if parameters.Mode == "" {
+		parameters.Mode = defaults.Mode
+	}
+
+	if parameters.Metrics.Source == "" {
+		parameters.Metrics.Source = defaults.Metrics.Source
+	}
+
+	if parameters.Metrics.ExporterURL == "" {
+		parameters.Metrics.ExporterURL = defaults.Metrics.ExporterURL
+	}
+
+	if parameters.Metrics.SampleInterval == "" {
+		parameters.Metrics.SampleInterval = defaults.Metrics.SampleInterval
+	}
+
+	if parameters.Metrics.ScaleDownTimeout == "" {
+		parameters.Metrics.ScaleDownTimeout = defaults.Metrics.ScaleDownTimeout
+	}
+
+	applyMappingDefaults(&parameters.Metrics.Mapping, defaults.Metrics.Mapping)
+
+	if parameters.Metrics.Prometheus.URL == "" {
+		parameters.Metrics.Prometheus.URL = defaults.Metrics.Prometheus.URL
+	}
+
+	if parameters.Metrics.Prometheus.CPUUsage == "" {
+		parameters.Metrics.Prometheus.CPUUsage = defaults.Metrics.Prometheus.CPUUsage
+	}
+
+	if parameters.Metrics.Prometheus.MemoryUsage == "" {
+		parameters.Metrics.Prometheus.MemoryUsage = defaults.Metrics.Prometheus.MemoryUsage
+	}
+
+	if parameters.Metrics.Prometheus.PodCount == "" {
+		parameters.Metrics.Prometheus.PodCount = defaults.Metrics.Prometheus.PodCount
+	}
+
+	if parameters.Metrics.Prometheus.PodNames == "" {
+		parameters.Metrics.Prometheus.PodNames = defaults.Metrics.Prometheus.PodNames
+	}
+
+	if parameters.Metrics.Prometheus.ResourceRequests == "" {
+		parameters.Metrics.Prometheus.ResourceRequests = defaults.Metrics.Prometheus.ResourceRequests
+	}
+
+	if parameters.Metrics.Prometheus.ResourceLimits == "" {
+		parameters.Metrics.Prometheus.ResourceLimits = defaults.Metrics.Prometheus.ResourceLimits
+	}
+
+	if parameters.Metrics.Prometheus.ContainerInfo == "" {
+		parameters.Metrics.Prometheus.ContainerInfo = defaults.Metrics.Prometheus.ContainerInfo
+	}
+
+	if parameters.Cooldown.Policy == "" {
+		parameters.Cooldown.Policy = defaults.Cooldown.Policy
+	}
+
+	if parameters.Cooldown.Timeout == "" {
+		parameters.Cooldown.Timeout = defaults.Cooldown.Timeout
+	}
+
+	if parameters.Cooldown.PollInterval == "" {
+		parameters.Cooldown.PollInterval = defaults.Cooldown.PollInterval
+	}
+
+	if parameters.Cooldown.CPUThreshold == 0 {
+		parameters.Cooldown.CPUThreshold = defaults.Cooldown.CPUThreshold
+	}
+
+	if parameters.Cost.Currency == "" {
+		parameters.Cost.Currency = defaults.Cost.Currency
+	}
+
+	if parameters.ColdStart.Iterations == 0 {
+		parameters.ColdStart.Iterations = defaults.ColdStart.Iterations
+	}
+
+	if parameters.ColdStart.ScaleToZeroTimeout == "" {
+		parameters.ColdStart.ScaleToZeroTimeout = defaults.ColdStart.ScaleToZeroTimeout
+	}
+
+	if parameters.ColdStart.PollInterval == "" {
+		parameters.ColdStart.PollInterval = defaults.ColdStart.PollInterval
+	}
+
+	if parameters.KeepAlive.Strategy == "" {
+		parameters.KeepAlive.Strategy = defaults.KeepAlive.Strategy
+	}
+
+	if parameters.KeepAlive.MinGap == "" {
+		parameters.KeepAlive.MinGap = defaults.KeepAlive.MinGap
+	}
+
+	if parameters.KeepAlive.MaxGap == "" {
+		parameters.KeepAlive.MaxGap = defaults.KeepAlive.MaxGap
+	}
+
+	if parameters.KeepAlive.Resolution == "" {
+		parameters.KeepAlive.Resolution = defaults.KeepAlive.Resolution
+	}
+
+	if parameters.KeepAlive.ColdFactor == 0 {
+		parameters.KeepAlive.ColdFactor = defaults.KeepAlive.ColdFactor
+	}
+
+	if parameters.Arrival.Mode == "" {
+		parameters.Arrival.Mode = defaults.Arrival.Mode
+	}
+
+	// Perfis de carga e o modo open só são suportados pelo gerador nativo
+	if parameters.Engine == "" && (parameters.LoadProfile != nil || parameters.Arrival.Mode == ArrivalOpen) {
+		parameters.Engine = EngineNative
+	}
+
+	if parameters.Engine == "" {
+		parameters.Engine = defaults.Engine
+	}
+
+	// No modo open as chegadas seguem um processo de Poisson, a menos que configurado
+	if parameters.Arrival.Distribution == "" {
+		if parameters.Arrival.Mode == ArrivalOpen {
+			parameters.Arrival.Distribution = DistributionPoisson
+		} else {
+			parameters.Arrival.Distribution = DistributionConstant
+		}
+	}
+
+	if parameters.Arrival.MaxInFlight == 0 {
+		parameters.Arrival.MaxInFlight = defaults.Arrival.MaxInFlight
+	}
+
+	if len(parameters.Percentiles) == 0 {
+		parameters.Percentiles = defaults.Percentiles
+	}
+
 	// Não aplicar defaults para Method e Timeout
 	// Deixar o hey usar seus próprios valores padrão
 	// if parameters.Hey.Method == "" {
@@ -54,3 +245,28 @@ func ApplyDefaults(parameters *BenchmarkParameters) {
 		parameters.Hey.CPUs = defaults.Hey.CPUs
 	}
 }
+
+// applyMappingDefaults completa cada associação com a métrica e a agregação padrão.
+// A métrica "-" (MetricDisabled) é mantida para que o campo continue desativado.
+func applyMappingDefaults(mapping *MetricMapping, defaults MetricMapping) {
+	bindings := []struct {
+		binding  *MetricBinding
+		defaults MetricBinding
+	}{
+		{&mapping.CPUUsage, defaults.CPUUsage},
+		{&mapping.MemoryUsage, defaults.MemoryUsage},
+		{&mapping.PodCount, defaults.PodCount},
+		{&mapping.ScaledPods, defaults.ScaledPods},
+		{&mapping.BootDuration, defaults.BootDuration},
+		{&mapping.StartedAt, defaults.StartedAt},
+	}
+
+	for _, b := range bindings {
+		if b.binding.Metric == "" {
+			b.binding.Metric = b.defaults.Metric
+		}
+		if b.binding.Aggregation == "" {
+			b.binding.Aggregation = b.defaults.Aggregation
+		}
+	}
+}
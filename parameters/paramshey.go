@@ -2,13 +2,14 @@ package parameters
 
 import (
 	"fmt"
+	"time"
 )
 
 // Este arquivo contém métodos para conversão de parâmetros do FaaSKubeBench
 // para argumentos do hey, com foco na coleta silenciosa de dados estruturados.
 //
 // Modificações realizadas:
-// - ToHeyArgs() agora força saída JSON e modo silencioso (-o json -q)
+// - ToHeyArgs() repassa -o csv quando configurado; sem -o o hey imprime o resumo textual
 // - Removida exibição desnecessária no terminal
 // - Adicionados métodos para configuração de coleta silenciosa
 // - Dados são coletados estruturadamente para posterior seleção e exibição
@@ -83,9 +84,10 @@ func (p *BenchmarkParameters) ToHeyArgs() []string {
 		args = append(args, "-disable-redirects")
 	}
 
-	// Force JSON output for structured parsing (hey outputs JSON by default when no -o flag is used)
-	// Não adicionar -o json pois não é um parâmetro válido do hey
-	// O hey produz saída JSON por padrão quando usado programaticamente
+	// Formato de saída: sem -o o hey imprime o resumo textual; com -o csv, uma linha por requisição
+	if p.Hey.Output != "" {
+		args = append(args, "-o", p.Hey.Output)
+	}
 
 	// URL final
 	args = append(args, p.URL)
@@ -104,6 +106,8 @@ func (p *BenchmarkParameters) ToEnvVars() map[string]string {
 	env["BENCH_FUNCTION"] = p.Function
 	env["BENCH_URL"] = p.URL
 	env["BENCH_WORKLOAD"] = p.Workload
+	env["BENCH_ENGINE"] = p.Engine
+	env["BENCH_MODE"] = p.Mode
 
 	// Adicionar parâmetros do hey
 	env["HEY_METHOD"] = p.Hey.Method
@@ -125,6 +129,26 @@ func (p *BenchmarkParameters) Clone() *BenchmarkParameters {
 		}
 	}
 
+	if p.LoadProfile != nil {
+		profile := *p.LoadProfile
+		profile.Stages = append([]LoadStage(nil), p.LoadProfile.Stages...)
+		clone.LoadProfile = &profile
+	}
+
+	if p.SweepPoint != nil {
+		clone.SweepPoint = make(map[string]string)
+		for k, v := range p.SweepPoint {
+			clone.SweepPoint[k] = v
+		}
+	}
+
+	if p.Percentiles != nil {
+		clone.Percentiles = make([]float64, len(p.Percentiles))
+		copy(clone.Percentiles, p.Percentiles)
+	}
+
+	clone.Metrics.Mapping = p.Metrics.Mapping.Clone()
+
 	if p.Hey.Headers != nil {
 		clone.Hey.Headers = make(map[string]string)
 		for k, v := range p.Hey.Headers {
@@ -137,7 +161,7 @@ func (p *BenchmarkParameters) Clone() *BenchmarkParameters {
 
 // ConfigureSilentDataCollection configura os parâmetros para coleta silenciosa de dados
 func (p *BenchmarkParameters) ConfigureSilentDataCollection() {
-	// O hey produz saída JSON por padrão, não precisamos forçar -o json
+	// Sem -o o hey imprime apenas o resumo textual ao final da execução
 	p.Hey.Output = ""
 
 	// Adiciona metadata indicando modo silencioso
@@ -145,7 +169,7 @@ func (p *BenchmarkParameters) ConfigureSilentDataCollection() {
 		p.Metadata = make(map[string]string)
 	}
 	p.Metadata["collection_mode"] = "silent"
-	p.Metadata["output_format"] = "json"
+	p.Metadata["output_format"] = "summary"
 }
 
 // IsSilentMode verifica se a coleta está configurada para modo silencioso
@@ -195,3 +219,46 @@ func (p *BenchmarkParameters) ValidateHeyArgs() []string {
 
 	return validArgs
 }
+
+// TotalDuration retorna a soma das durações dos estágios do perfil de carga
+func (lp *LoadProfile) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, stage := range lp.Stages {
+		d, _ := time.ParseDuration(stage.Duration)
+		total += d
+	}
+	return total
+}
+
+// Enabled indica se a associação tem uma métrica a coletar
+func (b MetricBinding) Enabled() bool {
+	return b.Metric != "" && b.Metric != MetricDisabled
+}
+
+// Bindings retorna as associações indexadas pelo nome do campo no YAML
+func (m MetricMapping) Bindings() map[string]MetricBinding {
+	return map[string]MetricBinding{
+		"cpu_usage":     m.CPUUsage,
+		"memory_usage":  m.MemoryUsage,
+		"pod_count":     m.PodCount,
+		"scaled_pods":   m.ScaledPods,
+		"boot_duration": m.BootDuration,
+		"started_at":    m.StartedAt,
+	}
+}
+
+// Clone cria uma cópia do mapeamento sem compartilhar os filtros de labels
+func (m MetricMapping) Clone() MetricMapping {
+	clone := m
+	for _, b := range []*MetricBinding{&clone.CPUUsage, &clone.MemoryUsage, &clone.PodCount, &clone.ScaledPods, &clone.BootDuration, &clone.StartedAt} {
+		if b.Labels == nil {
+			continue
+		}
+		labels := make(map[string]string, len(b.Labels))
+		for k, v := range b.Labels {
+			labels[k] = v
+		}
+		b.Labels = labels
+	}
+	return clone
+}
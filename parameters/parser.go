@@ -7,8 +7,9 @@ import (
 	"gopkg.in/yaml.v3" // Converter YAML em go
 )
 
-// Carregar parâmetros de um arquivo YAML
-func LoadParametersFromFile(filePath string) (*BenchmarkParameters, error) {
+// Carregar parâmetros de um arquivo YAML. Retorna um conjunto de parâmetros por
+// cenário e ponto de varredura, ou apenas um para um arquivo simples.
+func LoadParametersFromFile(filePath string) ([]*BenchmarkParameters, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %v", err)
@@ -17,27 +18,132 @@ func LoadParametersFromFile(filePath string) (*BenchmarkParameters, error) {
 	return LoadParametersFromYAML(data)
 }
 
-// Carregar parâmetros de dados YAML
-func LoadParametersFromYAML(data []byte) (*BenchmarkParameters, error) {
-	var parameters BenchmarkParameters
-	err := yaml.Unmarshal(data, &parameters)
-	if err != nil {
+// Carregar parâmetros de dados YAML. Um arquivo de campanha possui uma seção
+// "defaults" e uma lista "scenarios"; cada cenário sobrescreve os defaults.
+func LoadParametersFromYAML(data []byte) ([]*BenchmarkParameters, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %v", err)
 	}
 
-	// DEBUG: Valores antes dos defaults
-	//fmt.Printf("Antes ApplyDefaults - Method: '%s', Timeout: %d\n", parameters.Hey.Method, parameters.Hey.Timeout)
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("failed to parse YAML: empty document")
+	}
+	root := document.Content[0]
+
+	if root.Kind != yaml.MappingNode || mappingIndex(root, "scenarios") == -1 {
+		return resolveParameters(root)
+	}
+
+	return loadCampaign(root)
+}
+
+// loadCampaign resolve cada cenário de um arquivo de campanha sobre a seção de defaults
+func loadCampaign(root *yaml.Node) ([]*BenchmarkParameters, error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if key := root.Content[i].Value; key != "defaults" && key != "scenarios" {
+			return nil, fmt.Errorf("unknown campaign field: %s. Campaign files only accept defaults and scenarios", key)
+		}
+	}
+
+	var defaults *yaml.Node
+	if i := mappingIndex(root, "defaults"); i != -1 {
+		defaults = root.Content[i+1]
+		if defaults.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("campaign defaults must be a mapping")
+		}
+	}
+
+	scenarios := root.Content[mappingIndex(root, "scenarios")+1]
+	if scenarios.Kind != yaml.SequenceNode || len(scenarios.Content) == 0 {
+		return nil, fmt.Errorf("campaign scenarios must be a non-empty list")
+	}
+
+	var all []*BenchmarkParameters
+	names := make(map[string]bool)
+
+	for i, scenario := range scenarios.Content {
+		if scenario.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("scenario %d must be a mapping", i+1)
+		}
+
+		merged := mergeNodes(defaults, scenario)
+
+		// Cenários sem nome recebem um nome a partir da posição na lista
+		if mappingIndex(merged, "name") == -1 {
+			merged.Content = append(merged.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "name"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprintf("scenario-%d", i+1)},
+			)
+		}
+
+		points, err := resolveParameters(merged)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %d: %v", i+1, err)
+		}
+
+		name := points[0].Name
+		if names[name] {
+			return nil, fmt.Errorf("duplicate scenario name: %s", name)
+		}
+		names[name] = true
+
+		all = append(all, points...)
+	}
+
+	return all, nil
+}
+
+// resolveParameters decodifica um conjunto de parâmetros, aplica os defaults,
+// expande a varredura e valida cada ponto
+func resolveParameters(node *yaml.Node) ([]*BenchmarkParameters, error) {
+	var parameters BenchmarkParameters
+	if err := node.Decode(&parameters); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %v", err)
+	}
 
 	// Aplicar defaults
 	ApplyDefaults(&parameters)
 
-	// DEBUG: Valores depois dos defaults
-	//fmt.Printf("Depois ApplyDefaults - Method: '%s', Timeout: %d\n", parameters.Hey.Method, parameters.Hey.Timeout)
+	// Expandir a varredura e validar cada ponto
+	points := parameters.ExpandSweep()
+	for _, point := range points {
+		if err := ValidateParameters(point); err != nil {
+			if label := point.SweepLabel(); label != "" {
+				return nil, fmt.Errorf("sweep point [%s]: %v", label, err)
+			}
+			return nil, err
+		}
+	}
+
+	return points, nil
+}
+
+// mergeNodes combina dois mapeamentos YAML: chaves de override substituem as de
+// base, e mapeamentos aninhados (como "hey") são combinados recursivamente
+func mergeNodes(base, override *yaml.Node) *yaml.Node {
+	if base == nil {
+		return override
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: base.Tag, Style: base.Style}
+	merged.Content = append([]*yaml.Node(nil), base.Content...)
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+
+		j := mappingIndex(merged, key.Value)
+		if j == -1 {
+			merged.Content = append(merged.Content, key, value)
+			continue
+		}
 
-	// Validar parâmetros
-	if err := ValidateParameters(&parameters); err != nil {
-		return nil, err
+		if merged.Content[j+1].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+			merged.Content[j+1] = mergeNodes(merged.Content[j+1], value)
+		} else {
+			merged.Content[j+1] = value
+		}
 	}
 
-	return &parameters, nil
+	return merged
 }
@@ -0,0 +1,236 @@
+package parameters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SweepParameters guarda os valores das dimensões de varredura declaradas como
+// listas no YAML, por exemplo "concurrency: [10, 50, 100]"
+type SweepParameters struct {
+	Requests    []int
+	Concurrency []int
+	Time        []string
+	RateLimit   []int
+	Body        []string
+	BodyFile    []string
+}
+
+// sweepDimension descreve uma dimensão da varredura e como aplicá-la a um ponto
+type sweepDimension struct {
+	name  string
+	size  int
+	apply func(p *BenchmarkParameters, i int) string // retorna o rótulo do valor aplicado
+}
+
+// UnmarshalYAML aceita listas nos campos que admitem varredura. O primeiro valor
+// de cada lista é usado nos parâmetros base e a lista completa fica em Sweep.
+func (p *BenchmarkParameters) UnmarshalYAML(value *yaml.Node) error {
+	root := value
+	var sweep SweepParameters
+
+	if value.Kind == yaml.MappingNode {
+		var err error
+		root, err = extractSweep(value, []sweepTarget{
+			{"requests", &sweep.Requests},
+			{"concurrency", &sweep.Concurrency},
+			{"time", &sweep.Time},
+		})
+		if err != nil {
+			return err
+		}
+
+		if i := mappingIndex(root, "hey"); i != -1 && root.Content[i+1].Kind == yaml.MappingNode {
+			hey, err := extractSweep(root.Content[i+1], []sweepTarget{
+				{"rate_limit", &sweep.RateLimit},
+				{"body", &sweep.Body},
+				{"body_file", &sweep.BodyFile},
+			})
+			if err != nil {
+				return err
+			}
+			root.Content[i+1] = hey
+		}
+	}
+
+	// Tipo auxiliar sem o método UnmarshalYAML para evitar recursão
+	type plain BenchmarkParameters
+	var decoded plain
+	if err := root.Decode(&decoded); err != nil {
+		return err
+	}
+
+	*p = BenchmarkParameters(decoded)
+	p.Sweep = sweep
+	return nil
+}
+
+// sweepTarget associa uma chave do YAML à lista que recebe seus valores
+type sweepTarget struct {
+	key    string
+	values interface{}
+}
+
+// extractSweep retorna uma cópia do mapeamento em que cada lista das chaves
+// informadas é substituída pelo seu primeiro valor, decodificando a lista no destino
+func extractSweep(mapping *yaml.Node, targets []sweepTarget) (*yaml.Node, error) {
+	copied := *mapping
+	copied.Content = append([]*yaml.Node(nil), mapping.Content...)
+
+	for _, target := range targets {
+		i := mappingIndex(&copied, target.key)
+		if i == -1 || copied.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+
+		list := copied.Content[i+1]
+		if len(list.Content) == 0 {
+			return nil, fmt.Errorf("sweep list for %s cannot be empty", target.key)
+		}
+		if err := list.Decode(target.values); err != nil {
+			return nil, fmt.Errorf("invalid sweep values for %s: %v", target.key, err)
+		}
+
+		copied.Content[i+1] = list.Content[0]
+	}
+
+	return &copied, nil
+}
+
+// mappingIndex retorna o índice da chave em um nó de mapeamento, ou -1
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// dimensions lista as dimensões com mais de um valor, na ordem em que são expandidas
+func (s SweepParameters) dimensions() []sweepDimension {
+	var dims []sweepDimension
+
+	if len(s.Requests) > 1 {
+		dims = append(dims, sweepDimension{"requests", len(s.Requests), func(p *BenchmarkParameters, i int) string {
+			p.Requests = s.Requests[i]
+			return fmt.Sprintf("%d", p.Requests)
+		}})
+	}
+	if len(s.Concurrency) > 1 {
+		dims = append(dims, sweepDimension{"concurrency", len(s.Concurrency), func(p *BenchmarkParameters, i int) string {
+			p.Concurrency = s.Concurrency[i]
+			return fmt.Sprintf("%d", p.Concurrency)
+		}})
+	}
+	if len(s.Time) > 1 {
+		dims = append(dims, sweepDimension{"time", len(s.Time), func(p *BenchmarkParameters, i int) string {
+			p.Time = s.Time[i]
+			return p.Time
+		}})
+	}
+	if len(s.RateLimit) > 1 {
+		dims = append(dims, sweepDimension{"rate_limit", len(s.RateLimit), func(p *BenchmarkParameters, i int) string {
+			p.Hey.RateLimit = s.RateLimit[i]
+			return fmt.Sprintf("%d", p.Hey.RateLimit)
+		}})
+	}
+	if len(s.Body) > 1 {
+		// O corpo pode ser grande; o rótulo usa a posição na lista, que distingue
+		// corpos do mesmo tamanho, e o tamanho, como "#2 (17B)"
+		dims = append(dims, sweepDimension{"body", len(s.Body), func(p *BenchmarkParameters, i int) string {
+			p.Hey.Body = s.Body[i]
+			return fmt.Sprintf("#%d (%dB)", i+1, len(p.Hey.Body))
+		}})
+	}
+	if len(s.BodyFile) > 1 {
+		dims = append(dims, sweepDimension{"body_file", len(s.BodyFile), func(p *BenchmarkParameters, i int) string {
+			p.Hey.BodyFile = s.BodyFile[i]
+			return p.Hey.BodyFile
+		}})
+	}
+
+	return dims
+}
+
+// IsSweep indica se os parâmetros declaram alguma dimensão de varredura
+func (p *BenchmarkParameters) IsSweep() bool {
+	return len(p.Sweep.dimensions()) > 0
+}
+
+// ExpandSweep gera o produto cartesiano das dimensões de varredura, clonando os
+// parâmetros base para cada ponto. Sem varredura, retorna apenas um clone da base.
+func (p *BenchmarkParameters) ExpandSweep() []*BenchmarkParameters {
+	dims := p.Sweep.dimensions()
+
+	base := p.Clone()
+	base.Sweep = SweepParameters{}
+
+	if len(dims) == 0 {
+		return []*BenchmarkParameters{base}
+	}
+
+	total := 1
+	for _, d := range dims {
+		total *= d.size
+	}
+
+	points := make([]*BenchmarkParameters, 0, total)
+	indexes := make([]int, len(dims))
+
+	for n := 0; n < total; n++ {
+		point := base.Clone()
+		point.SweepPoint = make(map[string]string, len(dims))
+		for d, dim := range dims {
+			point.SweepPoint[dim.name] = dim.apply(point, indexes[d])
+		}
+		points = append(points, point)
+
+		// Avança os índices como um odômetro, a última dimensão variando mais rápido
+		for d := len(dims) - 1; d >= 0; d-- {
+			indexes[d]++
+			if indexes[d] < dims[d].size {
+				break
+			}
+			indexes[d] = 0
+		}
+	}
+
+	return points
+}
+
+// SweepLabel retorna as coordenadas do ponto de varredura, como "concurrency=50, requests=200"
+func (p *BenchmarkParameters) SweepLabel() string {
+	if len(p.SweepPoint) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(p.SweepPoint))
+	for k := range p.SweepPoint {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, p.SweepPoint[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Label identifica o conjunto de parâmetros pelo nome do cenário e pelas
+// coordenadas da varredura, como "knative-cpu [concurrency=50]"
+func (p *BenchmarkParameters) Label() string {
+	sweep := p.SweepLabel()
+	switch {
+	case p.Name != "" && sweep != "":
+		return fmt.Sprintf("%s [%s]", p.Name, sweep)
+	case p.Name != "":
+		return p.Name
+	default:
+		return sweep
+	}
+}
@@ -2,6 +2,9 @@ package parameters
 
 // Parâmetros para o FaaSKubeBench
 type BenchmarkParameters struct {
+	// Nome do cenário em arquivos de campanha
+	Name string `yaml:"name,omitempty"`
+
 	// Parâmetros principais da ferramenta
 	Requests    int    `yaml:"requests"`
 	Concurrency int    `yaml:"concurrency"`
@@ -9,13 +12,196 @@ type BenchmarkParameters struct {
 	Execution   int    `yaml:"execution,omitempty"`
 	Platform    string `yaml:"platform"`
 	Function    string `yaml:"function"`
+	Namespace   string `yaml:"namespace,omitempty"` // Namespace da função; vazio considera todos
 	URL         string `yaml:"url"`
 	Workload    string `yaml:"workload"`
+	Engine      string `yaml:"engine,omitempty"` // Gerador de carga: "hey" (padrão) ou "native"
+	Mode        string `yaml:"mode,omitempty"`   // Experimento: "load" (padrão), "coldstart" ou "keepalive"
+
+	// Percentis de latência (0-100) calculados a partir das medições individuais
+	Percentiles []float64 `yaml:"percentiles,omitempty"`
 
 	// Parâmetros específicos do Hey - Gerador de Carga
 	Hey HeyParameters `yaml:"hey,omitempty"`
 
+	// Perfil de carga com taxa alvo variável (requer o gerador nativo)
+	LoadProfile *LoadProfile `yaml:"load_profile,omitempty"`
+
+	// Modo de chegada das requisições: closed (padrão) ou open (requer o gerador nativo)
+	Arrival ArrivalParameters `yaml:"arrival,omitempty"`
+
+	// Origem das métricas do cluster
+	Metrics MetricsParameters `yaml:"metrics,omitempty"`
+
+	// Pausa entre execuções consecutivas
+	Cooldown CooldownParameters `yaml:"cooldown,omitempty"`
+
+	// Tabela de preços para estimar o custo das execuções
+	Cost CostParameters `yaml:"cost,omitempty"`
+
+	// Parâmetros do modo coldstart
+	ColdStart ColdStartParameters `yaml:"coldstart,omitempty"`
+
+	// Parâmetros do modo keepalive
+	KeepAlive KeepAliveParameters `yaml:"keepalive,omitempty"`
+
 	Metadata map[string]string `yaml:"metadata,omitempty"`
+
+	// Valores de varredura lidos do YAML e as coordenadas de um ponto já expandido
+	Sweep      SweepParameters   `yaml:"-"`
+	SweepPoint map[string]string `yaml:"-"`
+}
+
+// Geradores de carga suportados
+const (
+	EngineHey    = "hey"
+	EngineNative = "native"
+)
+
+// Modos de experimento
+const (
+	ModeLoad      = "load"      // carga com o gerador configurado
+	ModeColdStart = "coldstart" // uma requisição por vez, sempre com a função escalada para zero
+	ModeKeepAlive = "keepalive" // intervalos ociosos crescentes para estimar a janela de keep-alive
+)
+
+// Políticas de pausa entre execuções
+const (
+	CooldownFixed       = "fixed"    // espera a duração configurada
+	CooldownBaseline    = "baseline" // espera as réplicas voltarem à contagem de antes da primeira execução
+	CooldownScaleToZero = "zero"     // espera a função escalar para zero
+	CooldownCPUIdle     = "cpu_idle" // espera o uso de CPU da função ficar abaixo do limite
+)
+
+// CooldownParameters configura a pausa entre execuções, para que as execuções
+// repetidas comecem todas frias ou todas quentes, de propósito
+type CooldownParameters struct {
+	Policy       string  `yaml:"policy,omitempty"`
+	Duration     string  `yaml:"duration,omitempty"`      // política fixed; vazio ou 0s: execuções em sequência
+	Timeout      string  `yaml:"timeout,omitempty"`       // espera máxima das demais políticas
+	PollInterval string  `yaml:"poll_interval,omitempty"` // intervalo entre as leituras do cluster
+	CPUThreshold float64 `yaml:"cpu_threshold,omitempty"` // política cpu_idle, em millicores
+}
+
+// CostParameters é a tabela de preços usada para estimar o custo de cada execução.
+// Preços zerados não entram na estimativa; sem nenhum preço o custo não é calculado.
+type CostParameters struct {
+	Currency              string  `yaml:"currency,omitempty"`
+	PerGBSecond           float64 `yaml:"per_gb_second,omitempty"`
+	PerVCPUSecond         float64 `yaml:"per_vcpu_second,omitempty"`
+	PerMillionInvocations float64 `yaml:"per_million_invocations,omitempty"`
+	PerNodeHour           float64 `yaml:"per_node_hour,omitempty"` // clusters próprios
+	Nodes                 int     `yaml:"nodes,omitempty"`         // nós cobrados por hora
+
+	// Recursos alocados a cada réplica, para plataformas que cobram pelo tempo de vida
+	// da instância; zerados, os GB-segundos e vCPU-segundos vêm do uso amostrado
+	ReplicaMemoryMB float64 `yaml:"replica_memory_mb,omitempty"`
+	ReplicaVCPU     float64 `yaml:"replica_vcpu,omitempty"`
+}
+
+// Enabled indica se algum preço foi configurado
+func (c CostParameters) Enabled() bool {
+	return c.PerGBSecond > 0 || c.PerVCPUSecond > 0 || c.PerMillionInvocations > 0 || c.PerNodeHour > 0
+}
+
+// ColdStartParameters configura as sondagens de cold start
+type ColdStartParameters struct {
+	Iterations         int    `yaml:"iterations,omitempty"`
+	ScaleToZeroTimeout string `yaml:"scale_to_zero_timeout,omitempty"` // espera máxima até a função ficar sem pods
+	PollInterval       string `yaml:"poll_interval,omitempty"`         // intervalo entre consultas da contagem de pods
+}
+
+// Origens das métricas do cluster
+const (
+	MetricsExporter   = "exporter"   // exporter Prometheus iniciado com docker compose
+	MetricsKubernetes = "kubernetes" // API do Kubernetes e metrics.k8s.io, sem Docker
+	MetricsPrometheus = "prometheus" // consultas query_range a um Prometheus existente
+	MetricsNone       = "none"       // sem métricas do cluster, apenas as do gerador de carga
+)
+
+// MetricsParameters configura de onde vêm as métricas do cluster
+type MetricsParameters struct {
+	// Fonte registrada em metrics.RegisterSource: exporter, prometheus, kubernetes, none
+	// ou uma fonte própria
+	Source      string `yaml:"source,omitempty"`
+	ExporterURL string `yaml:"exporter_url,omitempty"`
+	Kubeconfig  string `yaml:"kubeconfig,omitempty"` // vazio: credenciais do pod, KUBECONFIG ou ~/.kube/config
+
+	// Intervalo entre as leituras contínuas feitas durante a carga
+	SampleInterval string `yaml:"sample_interval,omitempty"`
+
+	// Espera máxima, após a última execução, pelo retorno das réplicas à contagem
+	// de antes da execução ou a zero. O padrão 0s encerra as leituras com a carga;
+	// a espera se repete em cada cenário e ponto de varredura
+	ScaleDownTimeout string `yaml:"scale_down_timeout,omitempty"`
+
+	// Métricas do exporter usadas em cada campo do resultado
+	Mapping MetricMapping `yaml:"mapping,omitempty"`
+
+	// Consultas ao Prometheus do cluster quando source é prometheus
+	Prometheus PrometheusParameters `yaml:"prometheus,omitempty"`
+}
+
+// PrometheusParameters configura as consultas PromQL feitas sobre a janela da carga.
+// Nas consultas, $function, $namespace e $platform são substituídos pelos valores do cenário.
+type PrometheusParameters struct {
+	URL         string `yaml:"url,omitempty"`
+	CPUUsage    string `yaml:"cpu_usage,omitempty"`    // millicores
+	MemoryUsage string `yaml:"memory_usage,omitempty"` // bytes
+	PodCount    string `yaml:"pod_count,omitempty"`    // réplicas da função
+	PodNames    string `yaml:"pod_names,omitempty"`    // uma série por pod, com o label pod
+
+	// Requests e limits declarados, uma série por container com os labels pod e
+	// resource (cpu em cores, memory em bytes), como no kube-state-metrics
+	ResourceRequests string `yaml:"resource_requests,omitempty"`
+	ResourceLimits   string `yaml:"resource_limits,omitempty"`
+
+	// Containers de cada pod, uma série por container com os labels pod e container;
+	// os recursos só contam nos pods em que todos os containers os declaram
+	ContainerInfo string `yaml:"container_info,omitempty"`
+}
+
+// MetricDisabled desativa a coleta de um campo do mapeamento
+const MetricDisabled = "-"
+
+// Agregações das séries selecionadas de uma métrica
+const (
+	AggregationSum = "sum"
+	AggregationAvg = "avg"
+	AggregationMax = "max"
+)
+
+// MetricMapping associa cada métrica de orquestração do resultado a uma métrica do exporter
+type MetricMapping struct {
+	CPUUsage     MetricBinding `yaml:"cpu_usage,omitempty"`     // ClusterCPUUsage, em millicores
+	MemoryUsage  MetricBinding `yaml:"memory_usage,omitempty"`  // ClusterMemUsage, em bytes
+	PodCount     MetricBinding `yaml:"pod_count,omitempty"`     // réplicas da função
+	ScaledPods   MetricBinding `yaml:"scaled_pods,omitempty"`   // ScaledPodsDiff
+	BootDuration MetricBinding `yaml:"boot_duration,omitempty"` // TimeInicialization, em segundos
+	StartedAt    MetricBinding `yaml:"started_at,omitempty"`    // PodStartedAt, por label pod (opcional)
+}
+
+// MetricBinding indica a métrica, como agregar as séries e os labels que elas devem ter.
+// Sem metric vale a métrica padrão do campo; com metric "-" o campo não é coletado.
+type MetricBinding struct {
+	Metric      string            `yaml:"metric,omitempty"`
+	Aggregation string            `yaml:"aggregation,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+}
+
+// Estratégias de busca da janela de keep-alive
+const (
+	KeepAliveDoubling  = "doubling"  // dobra o intervalo ocioso até a primeira sondagem fria
+	KeepAliveBisection = "bisection" // busca binária entre min_gap e max_gap
+)
+
+// KeepAliveParameters configura a busca da janela de keep-alive
+type KeepAliveParameters struct {
+	Strategy   string  `yaml:"strategy,omitempty"`
+	MinGap     string  `yaml:"min_gap,omitempty"`     // menor intervalo ocioso avaliado
+	MaxGap     string  `yaml:"max_gap,omitempty"`     // maior intervalo ocioso avaliado
+	Resolution string  `yaml:"resolution,omitempty"`  // precisão desejada na busca binária
+	ColdFactor float64 `yaml:"cold_factor,omitempty"` // latência acima de cold_factor vezes a de referência indica cold start
 }
 
 // HeyParameters agrupa os parâmetros do hey
@@ -37,3 +223,59 @@ type HeyParameters struct {
 	CPUs               int               `yaml:"cpus,omitempty"`
 	Headers            map[string]string `yaml:"headers,omitempty"`
 }
+
+// Modos de chegada das requisições
+const (
+	ArrivalClosed = "closed" // cada worker envia a próxima requisição após a resposta anterior
+	ArrivalOpen   = "open"   // requisições enviadas em horários planejados, independentes das respostas
+)
+
+// Distribuições dos intervalos entre chegadas
+const (
+	DistributionPoisson  = "poisson"  // intervalos exponenciais
+	DistributionConstant = "constant" // intervalos iguais
+)
+
+// ArrivalParameters configura o agendamento das requisições
+type ArrivalParameters struct {
+	Mode         string  `yaml:"mode,omitempty"`
+	Distribution string  `yaml:"distribution,omitempty"`
+	Rate         float64 `yaml:"rate,omitempty"`          // req/s no modo open sem load_profile
+	MaxInFlight  int     `yaml:"max_in_flight,omitempty"` // limite de requisições simultâneas no modo open
+}
+
+// Tipos de estágio do perfil de carga
+const (
+	StageConstant = "constant" // taxa fixa (rps)
+	StageRamp     = "ramp"     // rampa linear de from_rps até to_rps
+	StageStep     = "step"     // escada de from_rps até to_rps em steps degraus
+	StageSpike    = "spike"    // taxa base rps com um pico de spike_rps
+	StageSine     = "sine"     // senoide em torno de rps com amplitude e period
+)
+
+// LoadProfile descreve a taxa alvo de requisições ao longo do tempo em estágios sucessivos
+type LoadProfile struct {
+	Stages []LoadStage `yaml:"stages"`
+}
+
+// LoadStage descreve um estágio do perfil de carga
+type LoadStage struct {
+	Name     string  `yaml:"name,omitempty"`
+	Type     string  `yaml:"type"`
+	Duration string  `yaml:"duration"`
+	RPS      float64 `yaml:"rps,omitempty"` // taxa fixa, base do pico ou centro da senoide
+
+	// Rampa e escada
+	FromRPS float64 `yaml:"from_rps,omitempty"`
+	ToRPS   float64 `yaml:"to_rps,omitempty"`
+	Steps   int     `yaml:"steps,omitempty"`
+
+	// Pico
+	SpikeRPS      float64 `yaml:"spike_rps,omitempty"`
+	SpikeStart    string  `yaml:"spike_start,omitempty"`    // relativo ao início do estágio
+	SpikeDuration string  `yaml:"spike_duration,omitempty"` // duração do pico
+
+	// Senoide
+	Amplitude float64 `yaml:"amplitude,omitempty"`
+	Period    string  `yaml:"period,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -25,6 +26,11 @@ func ValidateParameters(parameters *BenchmarkParameters) error {
 		return err
 	}
 
+	// Validar gerador de carga
+	if err := validateEngine(parameters.Engine); err != nil {
+		return err
+	}
+
 	// Validar URL
 	if err := validateURL(parameters.URL); err != nil {
 		return err
@@ -35,6 +41,60 @@ func ValidateParameters(parameters *BenchmarkParameters) error {
 		return err
 	}
 
+	// Validar perfil de carga
+	if err := validateLoadProfile(parameters); err != nil {
+		return err
+	}
+
+	// Validar origem das métricas
+	if err := validateMetricsSource(parameters.Metrics.Source); err != nil {
+		return err
+	}
+	if _, err := parsePositiveDuration("metrics sample_interval", parameters.Metrics.SampleInterval); err != nil {
+		return err
+	}
+	if d, err := time.ParseDuration(parameters.Metrics.ScaleDownTimeout); err != nil || d < 0 {
+		return fmt.Errorf("invalid metrics scale_down_timeout: %s. Use format like 0s, 5m", parameters.Metrics.ScaleDownTimeout)
+	}
+	if err := validateMapping(parameters.Metrics.Mapping); err != nil {
+		return err
+	}
+	switch parameters.Metrics.Source {
+	case MetricsExporter:
+		if err := validateURL(parameters.Metrics.ExporterURL); err != nil {
+			return fmt.Errorf("invalid metrics exporter_url: %v", err)
+		}
+	case MetricsPrometheus:
+		if err := validateURL(parameters.Metrics.Prometheus.URL); err != nil {
+			return fmt.Errorf("invalid metrics prometheus url: %v", err)
+		}
+	}
+
+	// Validar pausa entre execuções
+	if err := validateCooldown(parameters.Cooldown); err != nil {
+		return err
+	}
+
+	// Validar tabela de preços
+	if err := validateCost(parameters.Cost); err != nil {
+		return err
+	}
+
+	// Validar modo de experimento
+	if err := validateMode(parameters); err != nil {
+		return err
+	}
+
+	// Validar modo de chegada
+	if err := validateArrival(parameters); err != nil {
+		return err
+	}
+
+	// Validar percentis de latência
+	if err := validatePercentiles(parameters.Percentiles); err != nil {
+		return err
+	}
+
 	// Validar parâmetros do hey
 	if err := validateHeyParameters(&parameters.Hey); err != nil {
 		return err
@@ -107,6 +167,15 @@ func validateWorkload(workload string) error {
 	return nil
 }
 
+// validateEngine valida o gerador de carga
+func validateEngine(engine string) error {
+	if engine != EngineHey && engine != EngineNative {
+		return fmt.Errorf("unsupported engine: %s. Supported engines: hey, native", engine)
+	}
+
+	return nil
+}
+
 // validateURL valida a URL do endpoint
 func validateURL(urlStr string) error {
 	if urlStr == "" {
@@ -150,6 +219,314 @@ func validateTimeParameters(parameters *BenchmarkParameters) error {
 	return nil
 }
 
+// validateLoadProfile valida os estágios do perfil de carga
+func validateLoadProfile(parameters *BenchmarkParameters) error {
+	profile := parameters.LoadProfile
+	if profile == nil {
+		return nil
+	}
+
+	if parameters.Engine != EngineNative {
+		return fmt.Errorf("load_profile requires engine: native")
+	}
+
+	if parameters.Time != "" {
+		return fmt.Errorf("time and load_profile cannot be used together; the profile defines the duration")
+	}
+
+	if len(profile.Stages) == 0 {
+		return fmt.Errorf("load_profile must have at least one stage")
+	}
+
+	for i, stage := range profile.Stages {
+		if err := validateLoadStage(stage); err != nil {
+			return fmt.Errorf("load_profile stage %d: %v", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// validateLoadStage valida um estágio do perfil de carga
+func validateLoadStage(stage LoadStage) error {
+	duration, err := parsePositiveDuration("duration", stage.Duration)
+	if err != nil {
+		return err
+	}
+
+	if stage.RPS < 0 || stage.FromRPS < 0 || stage.ToRPS < 0 || stage.SpikeRPS < 0 {
+		return fmt.Errorf("rates cannot be negative")
+	}
+
+	switch stage.Type {
+	case StageConstant:
+		if stage.RPS <= 0 {
+			return fmt.Errorf("constant stage requires rps greater than 0")
+		}
+	case StageRamp:
+		if stage.FromRPS == 0 && stage.ToRPS == 0 {
+			return fmt.Errorf("ramp stage requires from_rps or to_rps greater than 0")
+		}
+	case StageStep:
+		if stage.Steps < 1 {
+			return fmt.Errorf("step stage requires steps greater than 0")
+		}
+		if stage.FromRPS == 0 && stage.ToRPS == 0 {
+			return fmt.Errorf("step stage requires from_rps or to_rps greater than 0")
+		}
+	case StageSpike:
+		if stage.SpikeRPS <= 0 {
+			return fmt.Errorf("spike stage requires spike_rps greater than 0")
+		}
+		spikeDuration, err := parsePositiveDuration("spike_duration", stage.SpikeDuration)
+		if err != nil {
+			return err
+		}
+		var spikeStart time.Duration
+		if stage.SpikeStart != "" {
+			if spikeStart, err = time.ParseDuration(stage.SpikeStart); err != nil || spikeStart < 0 {
+				return fmt.Errorf("invalid spike_start: %s", stage.SpikeStart)
+			}
+		}
+		if spikeStart+spikeDuration > duration {
+			return fmt.Errorf("spike must end within the stage duration")
+		}
+	case StageSine:
+		if _, err := parsePositiveDuration("period", stage.Period); err != nil {
+			return err
+		}
+		if stage.RPS <= 0 {
+			return fmt.Errorf("sine stage requires rps greater than 0")
+		}
+		if stage.Amplitude < 0 {
+			return fmt.Errorf("amplitude cannot be negative")
+		}
+	default:
+		return fmt.Errorf("unsupported stage type: %s. Supported types: constant, ramp, step, spike, sine", stage.Type)
+	}
+
+	return nil
+}
+
+// validateArrival valida o modo de chegada das requisições
+func validateArrival(parameters *BenchmarkParameters) error {
+	arrival := parameters.Arrival
+
+	if arrival.Distribution != DistributionPoisson && arrival.Distribution != DistributionConstant {
+		return fmt.Errorf("unsupported arrival distribution: %s. Supported distributions: poisson, constant", arrival.Distribution)
+	}
+
+	if arrival.Rate < 0 {
+		return fmt.Errorf("arrival rate cannot be negative")
+	}
+
+	if arrival.MaxInFlight < 0 {
+		return fmt.Errorf("arrival max_in_flight cannot be negative")
+	}
+
+	switch arrival.Mode {
+	case ArrivalClosed:
+		if arrival.Rate > 0 {
+			return fmt.Errorf("arrival rate is only used with arrival mode open; use rate_limit or load_profile in closed mode")
+		}
+		if arrival.Distribution == DistributionPoisson && parameters.LoadProfile == nil {
+			return fmt.Errorf("poisson arrivals in closed mode require a load_profile")
+		}
+	case ArrivalOpen:
+		if parameters.Engine != EngineNative {
+			return fmt.Errorf("arrival mode open requires engine: native")
+		}
+		if parameters.LoadProfile == nil && arrival.Rate <= 0 {
+			return fmt.Errorf("arrival mode open requires rate greater than 0 or a load_profile")
+		}
+		if parameters.LoadProfile != nil && arrival.Rate > 0 {
+			return fmt.Errorf("arrival rate and load_profile cannot be used together; the profile defines the rate")
+		}
+	default:
+		return fmt.Errorf("unsupported arrival mode: %s. Supported modes: closed, open", arrival.Mode)
+	}
+
+	return nil
+}
+
+// validateMetricsSource valida a origem das métricas do cluster
+func validateMetricsSource(source string) error {
+	// As fontes são registradas no pacote metrics, que confere o nome ao criar a fonte
+	if strings.TrimSpace(source) == "" {
+		return fmt.Errorf("metrics source cannot be empty")
+	}
+	return nil
+}
+
+// validateMapping valida as agregações das associações de métricas
+func validateMapping(mapping MetricMapping) error {
+	bindings := mapping.Bindings()
+	fields := make([]string, 0, len(bindings))
+	for field := range bindings {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		binding := bindings[field]
+		switch binding.Aggregation {
+		case AggregationSum, AggregationAvg, AggregationMax:
+		default:
+			return fmt.Errorf("unsupported aggregation for metrics mapping %s: %s. Supported aggregations: sum, avg, max", field, binding.Aggregation)
+		}
+	}
+	return nil
+}
+
+// validateCooldown valida a política de pausa entre execuções
+func validateCooldown(cooldown CooldownParameters) error {
+	switch cooldown.Policy {
+	case CooldownFixed:
+		if cooldown.Duration == "" {
+			return nil
+		}
+		d, err := time.ParseDuration(cooldown.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid cooldown duration: %s. Use format like 30s, 5m", cooldown.Duration)
+		}
+		if d < 0 {
+			return fmt.Errorf("cooldown duration cannot be negative")
+		}
+		return nil
+	case CooldownBaseline, CooldownScaleToZero, CooldownCPUIdle:
+	default:
+		return fmt.Errorf("unsupported cooldown policy: %s. Supported policies: fixed, baseline, zero, cpu_idle", cooldown.Policy)
+	}
+
+	if _, err := parsePositiveDuration("cooldown timeout", cooldown.Timeout); err != nil {
+		return err
+	}
+	if _, err := parsePositiveDuration("cooldown poll_interval", cooldown.PollInterval); err != nil {
+		return err
+	}
+	if cooldown.Policy == CooldownCPUIdle && cooldown.CPUThreshold <= 0 {
+		return fmt.Errorf("cooldown cpu_threshold must be greater than 0")
+	}
+
+	return nil
+}
+
+// validateCost valida a tabela de preços e os recursos alocados por réplica
+func validateCost(cost CostParameters) error {
+	values := []struct {
+		field string
+		value float64
+	}{
+		{"per_gb_second", cost.PerGBSecond},
+		{"per_vcpu_second", cost.PerVCPUSecond},
+		{"per_million_invocations", cost.PerMillionInvocations},
+		{"per_node_hour", cost.PerNodeHour},
+		{"replica_memory_mb", cost.ReplicaMemoryMB},
+		{"replica_vcpu", cost.ReplicaVCPU},
+	}
+	for _, v := range values {
+		if v.value < 0 {
+			return fmt.Errorf("cost %s cannot be negative", v.field)
+		}
+	}
+	if cost.Nodes < 0 {
+		return fmt.Errorf("cost nodes cannot be negative")
+	}
+	if cost.PerNodeHour > 0 && cost.Nodes == 0 {
+		return fmt.Errorf("cost nodes must be set when per_node_hour is used")
+	}
+	return nil
+}
+
+// validateMode valida o modo de experimento e os seus parâmetros
+func validateMode(parameters *BenchmarkParameters) error {
+	// Cold start e keep-alive dependem da contagem de réplicas da fonte de métricas
+	if (parameters.Mode == ModeColdStart || parameters.Mode == ModeKeepAlive) && parameters.Metrics.Source == MetricsNone {
+		return fmt.Errorf("mode %s requires a metrics source that counts replicas, not %s", parameters.Mode, MetricsNone)
+	}
+
+	switch parameters.Mode {
+	case ModeLoad:
+		return nil
+	case ModeColdStart:
+		coldStart := parameters.ColdStart
+		if coldStart.Iterations <= 0 {
+			return fmt.Errorf("coldstart iterations must be greater than 0")
+		}
+		if _, err := parsePositiveDuration("coldstart scale_to_zero_timeout", coldStart.ScaleToZeroTimeout); err != nil {
+			return err
+		}
+		if _, err := parsePositiveDuration("coldstart poll_interval", coldStart.PollInterval); err != nil {
+			return err
+		}
+		return nil
+	case ModeKeepAlive:
+		return validateKeepAlive(parameters.KeepAlive)
+	default:
+		return fmt.Errorf("unsupported mode: %s. Supported modes: load, coldstart, keepalive", parameters.Mode)
+	}
+}
+
+// validateKeepAlive valida os limites e a estratégia da busca da janela de keep-alive
+func validateKeepAlive(keepAlive KeepAliveParameters) error {
+	if keepAlive.Strategy != KeepAliveDoubling && keepAlive.Strategy != KeepAliveBisection {
+		return fmt.Errorf("unsupported keepalive strategy: %s. Supported strategies: doubling, bisection", keepAlive.Strategy)
+	}
+
+	minGap, err := parsePositiveDuration("keepalive min_gap", keepAlive.MinGap)
+	if err != nil {
+		return err
+	}
+	maxGap, err := parsePositiveDuration("keepalive max_gap", keepAlive.MaxGap)
+	if err != nil {
+		return err
+	}
+	if minGap >= maxGap {
+		return fmt.Errorf("keepalive min_gap must be smaller than max_gap")
+	}
+
+	resolution, err := parsePositiveDuration("keepalive resolution", keepAlive.Resolution)
+	if err != nil {
+		return err
+	}
+	// A bisseção sonda intervalos em segundos inteiros
+	if resolution < time.Second {
+		return fmt.Errorf("keepalive resolution must be at least 1s")
+	}
+
+	if keepAlive.ColdFactor <= 1 {
+		return fmt.Errorf("keepalive cold_factor must be greater than 1")
+	}
+
+	return nil
+}
+
+// parsePositiveDuration interpreta uma duração obrigatória e maior que zero
+func parsePositiveDuration(field, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, fmt.Errorf("%s is required", field)
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s. Use format like 30s, 5m", field, value)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be greater than 0", field)
+	}
+	return d, nil
+}
+
+// validatePercentiles valida os percentis de latência configurados
+func validatePercentiles(percentiles []float64) error {
+	for _, p := range percentiles {
+		if p <= 0 || p > 100 {
+			return fmt.Errorf("invalid percentile: %g. Percentiles must be in the range (0, 100]", p)
+		}
+	}
+
+	return nil
+}
+
 // validateHeyParams valida os parâmetros específicos do hey
 func validateHeyParameters(heyParameters *HeyParameters) error {
 	// Validar método HTTP
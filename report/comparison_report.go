@@ -0,0 +1,208 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
+)
+
+// ComparisonReportGenerator gera um único relatório em Markdown comparando
+// os resultados de vários cenários ou pontos de varredura
+type ComparisonReportGenerator struct {
+	Results []metrics.ConsolidatedMetrics
+}
+
+// NewComparisonReportGenerator cria uma nova instância do ComparisonReportGenerator
+func NewComparisonReportGenerator(results []metrics.ConsolidatedMetrics) *ComparisonReportGenerator {
+	return &ComparisonReportGenerator{
+		Results: results,
+	}
+}
+
+// Generate gera o relatório comparativo no caminho especificado
+func (r *ComparisonReportGenerator) Generate(filePath string) error {
+	content := r.generateMarkdown()
+
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ComparisonReportGenerator) generateMarkdown() string {
+	markdown := "# Relatório Comparativo FaaSKubeBench\n\n"
+	markdown += "## Comparação entre Cenários\n\n"
+	markdown += "| Cenário | RPS | Latência Média | Latência p99 | Taxa de Erros | Requisições | CPU (Cluster) | Memória (Cluster) | Pods Escalados |\n"
+	markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+
+	for i, m := range r.Results {
+		markdown += fmt.Sprintf("| %s | %s | %s | %.4f s | %.2f%% | %d | %.2f mCores | %.2f MB | %d |\n",
+			resultLabel(i, m),
+			formatMeanStdDev(m.RPS, m.Aggregates.RPS, "%.2f"),
+			formatMeanStdDev(m.AvgLatency, m.Aggregates.AvgLatency, "%.4f s"),
+			m.P99Latency,
+			m.ErrorRate*100,
+			m.TotalRequests,
+			m.ClusterCPUUsage,
+			m.ClusterMemUsage/(1024*1024),
+			m.ScaledPodsDiff,
+		)
+	}
+
+	markdown += r.samplingComparison()
+	markdown += r.efficiencyComparison()
+	markdown += r.costComparison()
+	markdown += r.coldStartComparison()
+	markdown += r.keepAliveComparison()
+
+	// Relatório completo de cada cenário, com os títulos rebaixados um nível
+	for i, m := range r.Results {
+		label := resultLabel(i, m)
+		m.Label = ""
+		single := NewReportGenerator(m).generateMarkdown()
+		single = strings.Replace(single, "# Relatório de Benchmark FaaSKubeBench", "# "+label, 1)
+		markdown += "\n" + demoteHeadings(single)
+	}
+
+	return markdown
+}
+
+// samplingComparison compara os picos das leituras contínuas do cluster
+func (r *ComparisonReportGenerator) samplingComparison() string {
+	markdown := ""
+	for i, m := range r.Results {
+		sm := m.Sampled
+		if sm == nil || len(sm.Samples) == 0 {
+			continue
+		}
+
+		if markdown == "" {
+			markdown = "\n## Comparação das Leituras Contínuas\n\n"
+			markdown += "| Cenário | CPU Média | CPU Máxima | Memória Média | Memória Máxima | Réplicas Médias | Réplicas Máximas |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		}
+		markdown += fmt.Sprintf("| %s | %.2f mCores | %.2f mCores | %.2f MB | %.2f MB | %.2f | %.0f |\n",
+			resultLabel(i, m), sm.CPU.Avg, sm.CPU.Max, sm.Memory.Avg/(1024*1024), sm.Memory.Max/(1024*1024), sm.Pods.Avg, sm.Pods.Max)
+	}
+	return markdown
+}
+
+// efficiencyComparison compara o consumo de recursos normalizado pela carga
+func (r *ComparisonReportGenerator) efficiencyComparison() string {
+	markdown := ""
+	for i, m := range r.Results {
+		ef := m.Efficiency
+		if ef == nil {
+			continue
+		}
+
+		if markdown == "" {
+			markdown = "\n## Comparação de Eficiência de Recursos\n\n"
+			markdown += "| Cenário | Req/Core-s | MB-s/Req | CPU/Request | CPU/Limit | Memória/Request | Memória/Limit |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		}
+		markdown += fmt.Sprintf("| %s | %.2f | %.4f | %s | %s | %s | %s |\n",
+			resultLabel(i, m), ef.RequestsPerCoreSecond, ef.MBSecondsPerRequest,
+			formatUtilization(ef.CPURequestUtilization), formatUtilization(ef.CPULimitUtilization),
+			formatUtilization(ef.MemoryRequestUtilization), formatUtilization(ef.MemoryLimitUtilization))
+	}
+	return markdown
+}
+
+// costComparison compara o custo estimado dos cenários com tabela de preços
+func (r *ComparisonReportGenerator) costComparison() string {
+	markdown := ""
+	for i, m := range r.Results {
+		c := m.Cost
+		if c == nil {
+			continue
+		}
+
+		if markdown == "" {
+			markdown = "\n## Comparação de Custo Estimado\n\n"
+			markdown += "| Cenário | Custo por Execução | Custo por 1000 Requisições | Custo Total | GB-s | vCPU-s | Moeda |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		}
+		markdown += fmt.Sprintf("| %s | %s | %.6f | %.6f | %.4f | %.4f | %s |\n",
+			resultLabel(i, m), formatMeanStdDev(c.PerRun.Mean, c.PerRun, "%.6f"), c.Total.Per1000Requests,
+			c.Total.Total, c.Total.GBSeconds, c.Total.VCPUSeconds, c.Pricing.Currency)
+	}
+	return markdown
+}
+
+// coldStartComparison compara as latências de cold start dos cenários no modo coldstart
+func (r *ComparisonReportGenerator) coldStartComparison() string {
+	markdown := ""
+	for i, m := range r.Results {
+		cs := m.ColdStart
+		if cs == nil {
+			continue
+		}
+
+		if markdown == "" {
+			markdown = "\n## Comparação de Cold Start\n\n"
+			markdown += "| Cenário | Plataforma | Sondagens Válidas | Média | Mediana | Desvio Padrão | Mínimo | Máximo |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		}
+		markdown += fmt.Sprintf("| %s | %s | %d/%d | %.4f s | %.4f s | %.4f s | %.4f s | %.4f s |\n",
+			resultLabel(i, m), cs.Platform, cs.Latency.N, len(cs.Samples),
+			cs.Latency.Mean, cs.Latency.Median, cs.Latency.StdDev, cs.Latency.Min, cs.Latency.Max)
+	}
+	return markdown
+}
+
+// keepAliveComparison compara as janelas de keep-alive estimadas no modo keepalive
+func (r *ComparisonReportGenerator) keepAliveComparison() string {
+	markdown := ""
+	for i, m := range r.Results {
+		ka := m.KeepAlive
+		if ka == nil {
+			continue
+		}
+
+		if markdown == "" {
+			markdown = "\n## Comparação da Janela de Keep-Alive\n\n"
+			markdown += "| Cenário | Plataforma | Estratégia | Janela Estimada | Último Quente | Primeiro Frio | Sondagens |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		}
+
+		window, firstCold := ka.Window.String(), ka.FirstColdGap.String()
+		if !ka.Found {
+			window, firstCold = "> "+ka.LastWarmGap.String(), "N/A"
+		}
+		markdown += fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %d |\n",
+			resultLabel(i, m), ka.Platform, ka.Strategy, window, ka.LastWarmGap, firstCold, len(ka.Probes))
+	}
+	return markdown
+}
+
+// resultLabel retorna o rótulo do resultado, ou a sua posição quando não há rótulo
+func resultLabel(index int, m metrics.ConsolidatedMetrics) string {
+	if m.Label != "" {
+		return m.Label
+	}
+	return fmt.Sprintf("Cenário %d", index+1)
+}
+
+// formatMeanStdDev formata a média com o desvio padrão entre execuções, quando houver mais de uma
+func formatMeanStdDev(value float64, s metrics.Stats, valueFormat string) string {
+	if s.N < 2 {
+		return fmt.Sprintf(valueFormat, value)
+	}
+	return fmt.Sprintf(valueFormat+" ± "+valueFormat, s.Mean, s.StdDev)
+}
+
+// demoteHeadings rebaixa em um nível todos os títulos Markdown do texto
+func demoteHeadings(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
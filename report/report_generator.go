@@ -2,9 +2,12 @@ package report
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/mariaisadora-github/FaaSKubeBench/heyexec"
 	"github.com/mariaisadora-github/FaaSKubeBench/metrics"
 )
 
@@ -68,16 +71,136 @@ func (r *ReportGenerator) generateMarkdown() string {
 	}
 
 	markdown := "# Relatório de Benchmark FaaSKubeBench\n\n"
-	markdown += "## 1. Métricas de Desempenho (Hey)\n\n"
-	markdown += "| Métrica | Valor |\n"
-	markdown += "| :--- | :--- |\n"
-	markdown += fmt.Sprintf("| Requisições por Segundo (RPS) | %.2f |\n", m.RPS)
-	markdown += fmt.Sprintf("| Latência Média | %.4f s |\n", m.AvgLatency)
-	markdown += fmt.Sprintf("| Latência de Cauda (p99) | %.4f s |\n", m.P99Latency)
-	markdown += fmt.Sprintf("| Total de Requisições | %d |\n", m.TotalRequests)
-	markdown += fmt.Sprintf("| Taxa de Erros HTTP (4xx/5xx) | %s |\n", formatPercent(m.ErrorRate))
-	markdown += fmt.Sprintf("| Tráfego de Dados Total | %s |\n", formatBytes(float64(m.TotalData)))
-	markdown += fmt.Sprintf("| Tempo de Inicialização | %s |\n", formatDuration(m.TimeInicialization))
+	if m.Label != "" {
+		markdown += fmt.Sprintf("**%s**\n\n", m.Label)
+	}
+	// Nos modos coldstart e keepalive não há execuções de carga; a seção 3 traz as sondagens
+	if len(m.Executions) > 0 {
+		markdown += "## 1. Métricas de Desempenho (Hey)\n\n"
+		markdown += "| Métrica | Valor |\n"
+		markdown += "| :--- | :--- |\n"
+		markdown += fmt.Sprintf("| Requisições por Segundo (RPS) | %.2f |\n", m.RPS)
+		markdown += fmt.Sprintf("| Latência Média | %.4f s |\n", m.AvgLatency)
+		markdown += fmt.Sprintf("| Latência de Cauda (p99) | %.4f s |\n", m.P99Latency)
+		markdown += fmt.Sprintf("| Total de Requisições | %d |\n", m.TotalRequests)
+		markdown += fmt.Sprintf("| Taxa de Erros HTTP (4xx/5xx) | %s |\n", formatPercent(m.ErrorRate))
+		markdown += fmt.Sprintf("| Tráfego de Dados Total | %s |\n", formatBytes(float64(m.TotalData)))
+		markdown += fmt.Sprintf("| Tempo de Inicialização | %s |\n", formatDuration(m.TimeInicialization))
+
+		if len(m.Executions) > 1 {
+			markdown += "\n### 1.1 Estatísticas entre Execuções\n\n"
+			markdown += "| Métrica | Média | Mediana | Desvio Padrão | Mínimo | Máximo | IC 95% |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+			markdown += formatStatsRow("RPS (req/s)", m.Aggregates.RPS, "%.2f")
+			markdown += formatStatsRow("Latência Média (s)", m.Aggregates.AvgLatency, "%.4f")
+			markdown += formatStatsRow("Latência p99 (s)", m.Aggregates.P99Latency, "%.4f")
+			markdown += formatStatsRow("Taxa de Erros (fração)", m.Aggregates.ErrorRate, "%.4f")
+		}
+
+		if len(m.Executions) > 0 {
+			markdown += "\n### 1.2 Resultados por Execução\n\n"
+			markdown += "| Execução | RPS | Latência Média | Latência p99 | Requisições | Taxa de Erros | Pausa Anterior | Status |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+			for _, e := range m.Executions {
+				status := "OK"
+				if e.Error != "" {
+					status = "Falha"
+				}
+				markdown += fmt.Sprintf("| %d | %.2f | %.4f s | %.4f s | %d | %s | %s | %s |\n",
+					e.Execution, e.RPS, e.AvgLatency, e.P99Latency, e.TotalRequests, formatPercent(e.ErrorRate), formatCooldown(e.Cooldown), status)
+			}
+		}
+
+		if len(m.Aggregates.Percentiles) > 0 {
+			markdown += "\n### 1.3 Percentis de Latência (medições individuais)\n\n"
+			markdown += "| Percentil | Média | Desvio Padrão | Mínimo | Máximo |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- |\n"
+			for _, pct := range m.Aggregates.Percentiles {
+				markdown += fmt.Sprintf("| p%g | %.4f s | %.4f s | %.4f s | %.4f s |\n",
+					pct.Percentile, pct.Mean, pct.StdDev, pct.Min, pct.Max)
+			}
+		}
+
+		if m.LatencyHistogram != nil {
+			markdown += "\n### 1.4 Distribuição Combinada de Latência (todas as execuções)\n\n"
+			markdown += fmt.Sprintf("Total de amostras: %d\n\n", m.LatencyHistogram.TotalCount())
+			if m.ApproximateLatency {
+				markdown += "Distribuição aproximada: sem medições individuais em todas as execuções, as contagens vêm das faixas do resumo do hey, registradas no limite superior de cada faixa. O p99 da seção 1 é a média do p99 informado pelo hey em cada execução. Com o gerador nativo ou `hey.output: csv` os percentis são exatos.\n\n"
+			}
+			if len(m.PooledPercentiles) > 0 {
+				markdown += "| Percentil | Latência |\n"
+				markdown += "| :--- | :--- |\n"
+				for _, pct := range m.PooledPercentiles {
+					markdown += fmt.Sprintf("| p%g | %.4f s |\n", pct.Percentage*100, pct.Latency)
+				}
+				markdown += "\n"
+			}
+			markdown += "| Faixa de Latência | Requisições |\n"
+			markdown += "| :--- | :--- |\n"
+			for _, b := range m.LatencyHistogram.DisplayBuckets(0) {
+				markdown += fmt.Sprintf("| %.4f s – %.4f s | %d |\n", b.From, b.To, b.Count)
+			}
+		}
+
+		if len(m.Stages) > 0 {
+			markdown += "\n### 1.5 Estágios do Perfil de Carga\n\n"
+			markdown += "| Estágio | Tipo | Janela | RPS Alvo | RPS Atingido | Requisições | Latência Média | Latência p99 | Taxa de Erros |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+			for _, st := range m.Stages {
+				name := st.Name
+				if name == "" {
+					name = fmt.Sprintf("%d", st.Index+1)
+				}
+				markdown += fmt.Sprintf("| %s | %s | %.0fs – %.0fs | %.2f | %.2f | %d | %.4f s | %.4f s | %s |\n",
+					name, st.Type, st.Start, st.End, st.TargetRPS, st.AchievedRPS.Mean, st.Requests,
+					st.AvgLatency, st.P99Latency, formatPercent(st.ErrorRate))
+			}
+		}
+
+		if ol := m.OpenLoop; ol != nil {
+			markdown += "\n### 1.6 Modo Open (chegadas independentes das respostas)\n\n"
+			markdown += "| Métrica | Média | p99 |\n"
+			markdown += "| :--- | :--- | :--- |\n"
+			markdown += fmt.Sprintf("| Tempo de Resposta (desde o horário planejado) | %.4f s | %.4f s |\n", ol.AvgResponseTime, ol.P99ResponseTime)
+			markdown += fmt.Sprintf("| Tempo de Serviço (desde o envio efetivo) | %.4f s | %.4f s |\n", ol.AvgServiceTime, ol.P99ServiceTime)
+			markdown += fmt.Sprintf("\nAtraso de envio: média %.4f s, máximo %.4f s, %d requisições atrasadas (> 1 ms).\n",
+				ol.AvgSchedulingDelay, ol.MaxSchedulingDelay, ol.LateRequests)
+		}
+
+		if cw := m.ColdWarm; cw != nil {
+			markdown += "\n### 1.7 Requisições Frias e Quentes\n\n"
+			markdown += fmt.Sprintf("Frias: %d durante a inicialização de uma réplica nova e %d pelo modo lento da distribuição de latência.\n\n",
+				cw.ByReplica, cw.ByLatency)
+			if fit := cw.Bimodal; fit != nil {
+				markdown += fmt.Sprintf("Distribuição bimodal: modo rápido em %.4f s, modo lento em %.4f s (%.2f%% das requisições, separação D = %.2f); limite observado %.4f s.\n\n",
+					fit.WarmLatency, fit.ColdLatency, fit.ColdWeight*100, fit.Separation, fit.Threshold)
+			}
+			markdown += "| População | Requisições | Percentual | Média | Desvio Padrão | Mínimo | Máximo |"
+			for _, pct := range cw.Warm.Percentiles {
+				markdown += fmt.Sprintf(" p%g |", pct.Percentage*100)
+			}
+			markdown += "\n| :--- | :--- | :--- | :--- | :--- | :--- | :--- |"
+			for range cw.Warm.Percentiles {
+				markdown += " :--- |"
+			}
+			markdown += "\n"
+			for _, row := range []struct {
+				name string
+				pop  metrics.LatencyPopulation
+			}{{"Frias", cw.Cold}, {"Quentes", cw.Warm}} {
+				markdown += fmt.Sprintf("| %s | %d | %s | %.4f s | %.4f s | %.4f s | %.4f s |",
+					row.name, row.pop.Count, formatPercent(row.pop.Percentage), row.pop.Mean, row.pop.StdDev, row.pop.Min, row.pop.Max)
+				for i := range cw.Warm.Percentiles {
+					if i < len(row.pop.Percentiles) {
+						markdown += fmt.Sprintf(" %.4f s |", row.pop.Percentiles[i].Latency)
+					} else {
+						markdown += " N/A |"
+					}
+				}
+				markdown += "\n"
+			}
+		}
+	}
 
 	markdown += "\n## 2. Métricas de Orquestração (Kubernetes Exporter)\n\n"
 	markdown += "| Métrica | Valor |\n"
@@ -86,9 +209,382 @@ func (r *ReportGenerator) generateMarkdown() string {
 	markdown += fmt.Sprintf("| Consumo de CPU (Cluster Total) | %s |\n", formatMillicores(m.ClusterCPUUsage))
 	markdown += fmt.Sprintf("| Uso de Memória (Cluster Total) | %s |\n", formatBytes(m.ClusterMemUsage))
 
+	if sm := m.Sampled; sm != nil && len(sm.Samples) > 0 {
+		markdown += fmt.Sprintf("\n### 2.1 Leituras Contínuas durante a Carga (%d amostras a cada %s)\n\n", len(sm.Samples), sm.Interval)
+		markdown += "| Métrica | Mínimo | Média | p95 | Máximo |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- |\n"
+		markdown += fmt.Sprintf("| CPU | %s | %s | %s | %s |\n",
+			formatMillicores(sm.CPU.Min), formatMillicores(sm.CPU.Avg), formatMillicores(sm.CPU.P95), formatMillicores(sm.CPU.Max))
+		markdown += fmt.Sprintf("| Memória | %s | %s | %s | %s |\n",
+			formatBytes(sm.Memory.Min), formatBytes(sm.Memory.Avg), formatBytes(sm.Memory.P95), formatBytes(sm.Memory.Max))
+		markdown += fmt.Sprintf("| Réplicas | %.0f | %.2f | %.0f | %.0f |\n", sm.Pods.Min, sm.Pods.Avg, sm.Pods.P95, sm.Pods.Max)
+		if sm.Errors > 0 {
+			markdown += fmt.Sprintf("\nLeituras com falha: %d.\n", sm.Errors)
+		}
+
+		markdown += "\n| Instante | CPU | Memória | Réplicas |\n"
+		markdown += "| :--- | :--- | :--- | :--- |\n"
+		start := sm.Samples[0].Timestamp
+		for _, sample := range sm.Samples {
+			markdown += fmt.Sprintf("| +%.1fs | %s | %s | %d |\n",
+				sample.Timestamp.Sub(start).Seconds(), formatMillicores(sample.CPU), formatBytes(sample.Memory), sample.Pods)
+		}
+	}
+
+	if hasSnapshots(m.Executions) {
+		markdown += "\n### 2.2 Snapshots antes e depois de cada Execução\n\n"
+		markdown += "| Execução | Pods Antes | Pods Depois | Variação de Pods | Novos Pods | Variação de CPU | Variação de Memória |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, e := range m.Executions {
+			snap := e.Snapshot
+			if snap == nil {
+				continue
+			}
+			if snap.Error != "" {
+				markdown += fmt.Sprintf("| %d | - | - | - | - | - | falha: %s |\n", e.Execution, snap.Error)
+				continue
+			}
+			markdown += fmt.Sprintf("| %d | %d | %d | %+d | %s | %+.2f mCores | %s%s |\n",
+				e.Execution, snap.Before.Pods, snap.After.Pods, snap.PodDelta, formatPodNames(snap.NewPods),
+				snap.CPUDelta, signOf(snap.MemoryDelta), formatBytes(math.Abs(snap.MemoryDelta)))
+		}
+	}
+
+	if hasClusters(m.Executions) {
+		markdown += "\n### 2.3 Métricas do Cluster por Execução\n\n"
+		markdown += "| Execução | Janela | Pods Escalados | CPU Média | Memória Média | CPU Máxima | Réplicas Máximas |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, e := range m.Executions {
+			c := e.Cluster
+			if c == nil {
+				continue
+			}
+			if c.Error != "" {
+				markdown += fmt.Sprintf("| %d | %s | - | - | - | - | falha: %s |\n", e.Execution, c.Window.Duration().Round(time.Millisecond), c.Error)
+				continue
+			}
+			peakCPU, peakPods := "N/A", "N/A"
+			if c.Sampled != nil && len(c.Sampled.Samples) > 0 {
+				peakCPU = formatMillicores(c.Sampled.CPU.Max)
+				peakPods = fmt.Sprintf("%.0f", c.Sampled.Pods.Max)
+			}
+			markdown += fmt.Sprintf("| %d | %s | %d | %s | %s | %s | %s |\n",
+				e.Execution, c.Window.Duration().Round(time.Millisecond), c.ScaledPodsDiff,
+				formatMillicores(c.ClusterCPUUsage), formatBytes(c.ClusterMemUsage), peakCPU, peakPods)
+		}
+	}
+
+	if st := m.Startup; st != nil {
+		markdown += "\n### 2.4 Decomposição da Inicialização dos Pods Novos\n\n"
+		markdown += fmt.Sprintf("Pods criados durante a carga: %d (imagem já presente no nó em %d).\n\n", len(st.Pods), st.CachedImages)
+		markdown += "| Fase | Média | Mediana | Desvio Padrão | Mínimo | Máximo | IC 95% |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		markdown += formatStatsRow("Criação → Agendamento (s)", st.Scheduling, "%.3f")
+		markdown += formatStatsRow("Agendamento → Imagem Disponível (s)", st.ImagePull, "%.3f")
+		markdown += formatStatsRow("Imagem → Containers em Execução (s)", st.ContainerStart, "%.3f")
+		markdown += formatStatsRow("Containers em Execução → Ready (s)", st.Readiness, "%.3f")
+		markdown += formatStatsRow("Total: Criação → Ready (s)", st.Total, "%.3f")
+
+		markdown += "\n| Pod | Agendamento | Imagem | Containers | Ready | Total | Observação |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, p := range st.Pods {
+			note := ""
+			switch {
+			case p.Error != "":
+				note = "eventos indisponíveis: " + p.Error
+			case p.Ready.IsZero():
+				note = "ainda não ficou Ready"
+			case p.ImageCached:
+				note = "imagem em cache"
+			}
+			markdown += fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n", p.Pod,
+				formatPhase(p.Created, p.Scheduled), formatPhase(p.Scheduled, p.Pulled), formatPhase(p.Pulled, p.Started),
+				formatPhase(p.Started, p.Ready), formatPhase(p.Created, p.Ready), note)
+		}
+	}
+
+	if hasAutoscaling(m.Executions) {
+		markdown += "\n### 2.5 Linha do Tempo do Autoscaling por Execução\n\n"
+		markdown += "Subida contada do início da execução; retorno à referência contado do fim da carga, observado até o início da execução seguinte ou, na última, até o fim da carga, ou até as réplicas voltarem quando `metrics.scale_down_timeout` está configurado.\n\n"
+		markdown += "| Execução | Réplicas Antes | Pico | Primeira Subida | Tempo até o Pico | Taxa de Subida | Retorno à Referência | Escalou para Zero |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, e := range m.Executions {
+			a := e.Autoscaling
+			if a == nil {
+				continue
+			}
+			firstUp, toPeak, rate, back := "sem subida", "-", "-", "-"
+			if a.ScaledUp {
+				firstUp = a.TimeToFirstScaleUp.Round(time.Millisecond).String()
+				toPeak = a.TimeToPeak.Round(time.Millisecond).String()
+				rate = fmt.Sprintf("%.3f réplicas/s", a.ScaleUpRate)
+				back = "não observado"
+				if a.ReturnedToBaseline {
+					back = a.TimeToBaseline.Round(time.Millisecond).String()
+				}
+			}
+			zero := "não"
+			if a.ScaledToZero {
+				zero = "sim"
+			}
+			markdown += fmt.Sprintf("| %d | %d | %d | %s | %s | %s | %s | %s |\n",
+				e.Execution, a.Baseline, a.PeakReplicas, firstUp, toPeak, rate, back, zero)
+		}
+	}
+
+	if ef := m.Efficiency; ef != nil {
+		markdown += "\n### 2.6 Eficiência de Recursos\n\n"
+		markdown += "Uso amostrado dos pods da função integrado sobre a janela de carga das execuções bem-sucedidas.\n\n"
+		markdown += "| Métrica | Valor |\n"
+		markdown += "| :--- | :--- |\n"
+		markdown += fmt.Sprintf("| Requisições por Core-Segundo | %.2f |\n", ef.RequestsPerCoreSecond)
+		markdown += fmt.Sprintf("| MB-Segundos por Requisição | %.4f |\n", ef.MBSecondsPerRequest)
+		markdown += fmt.Sprintf("| CPU Consumida | %.2f core-s |\n", ef.CPUCoreSeconds)
+		markdown += fmt.Sprintf("| Memória Consumida | %.2f MB-s |\n", ef.MemoryMBSeconds)
+		markdown += fmt.Sprintf("| Réplicas × Tempo | %.2f réplica-s |\n", ef.ReplicaSeconds)
+
+		if res := ef.Resources; res != nil {
+			markdown += fmt.Sprintf("\nEspecificação por réplica (média de %d réplicas lidas):\n\n", res.Pods)
+			markdown += "| Recurso | Request | Utilização do Request | Limit | Utilização do Limit |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- |\n"
+			markdown += fmt.Sprintf("| CPU | %s | %s | %s | %s |\n",
+				formatDeclared(res.CPURequest, formatMillicores), formatUtilization(ef.CPURequestUtilization),
+				formatDeclared(res.CPULimit, formatMillicores), formatUtilization(ef.CPULimitUtilization))
+			markdown += fmt.Sprintf("| Memória | %s | %s | %s | %s |\n",
+				formatDeclared(res.MemoryRequest, formatBytes), formatUtilization(ef.MemoryRequestUtilization),
+				formatDeclared(res.MemoryLimit, formatBytes), formatUtilization(ef.MemoryLimitUtilization))
+		} else {
+			markdown += "\nA fonte de métricas não leu a especificação dos pods (apenas as fontes kubernetes e prometheus leem requests e limits); a utilização relativa a eles não foi calculada.\n\n"
+			markdown += "| Recurso | Request | Utilização do Request | Limit | Utilização do Limit |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- |\n"
+			markdown += "| CPU | N/A | N/A | N/A | N/A |\n"
+			markdown += "| Memória | N/A | N/A | N/A | N/A |\n"
+		}
+
+		markdown += "\n| Execução | Requisições | Req/Core-s | MB-s/Req | CPU/Request | Memória/Request |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, e := range m.Executions {
+			x := e.Efficiency
+			if x == nil {
+				continue
+			}
+			markdown += fmt.Sprintf("| %d | %d | %.2f | %.4f | %s | %s |\n",
+				e.Execution, x.Requests, x.RequestsPerCoreSecond, x.MBSecondsPerRequest,
+				formatUtilization(x.CPURequestUtilization), formatUtilization(x.MemoryRequestUtilization))
+		}
+	}
+
+	if c := m.Cost; c != nil {
+		pricing := c.Pricing
+		markdown += "\n## 3. Custo Estimado\n\n"
+		markdown += "Recursos integrados sobre a janela de carga de cada execução e sobre o tempo de vida das réplicas depois dela, até o início da execução seguinte ou, na última, até o fim da observação da redução de réplicas. "
+		if pricing.ReplicaMemoryMB > 0 {
+			markdown += fmt.Sprintf("Memória cobrada pela alocação (%.0f MB por réplica × tempo de vida das réplicas). ", pricing.ReplicaMemoryMB)
+		} else {
+			markdown += "Memória cobrada pelo uso amostrado. "
+		}
+		if pricing.ReplicaVCPU > 0 {
+			markdown += fmt.Sprintf("CPU cobrada pela alocação (%g vCPU por réplica × tempo de vida das réplicas).\n\n", pricing.ReplicaVCPU)
+		} else {
+			markdown += "CPU cobrada pelo uso amostrado.\n\n"
+		}
+		if c.Total.Samples == 0 && (pricing.PerGBSecond > 0 || pricing.PerVCPUSecond > 0) {
+			markdown += "Sem leituras do cluster na janela das execuções: os custos de memória e CPU ficaram zerados.\n\n"
+		}
+
+		t := c.Total
+		markdown += "| Componente | Quantidade | Preço Unitário | Custo |\n"
+		markdown += "| :--- | :--- | :--- | :--- |\n"
+		markdown += fmt.Sprintf("| Memória | %.4f GB-s | %g %s/GB-s | %.6f %s |\n", t.GBSeconds, pricing.PerGBSecond, pricing.Currency, t.Memory, pricing.Currency)
+		markdown += fmt.Sprintf("| CPU | %.4f vCPU-s | %g %s/vCPU-s | %.6f %s |\n", t.VCPUSeconds, pricing.PerVCPUSecond, pricing.Currency, t.CPU, pricing.Currency)
+		markdown += fmt.Sprintf("| Invocações | %d | %g %s/milhão | %.6f %s |\n", t.Requests, pricing.PerMillionInvocations, pricing.Currency, t.Invocations, pricing.Currency)
+		markdown += fmt.Sprintf("| Nós | %.6f nó-hora (%d nós) | %g %s/nó-hora | %.6f %s |\n", t.NodeHours, pricing.Nodes, pricing.PerNodeHour, pricing.Currency, t.Nodes, pricing.Currency)
+		markdown += fmt.Sprintf("| **Total** | %.2f réplica-s | - | **%.6f %s** |\n", t.ReplicaSeconds, t.Total, pricing.Currency)
+		if t.PostLoadDuration > 0 {
+			markdown += fmt.Sprintf("| Depois da Carga (incluído no total) | %.2f réplica-s em %s | - | %.6f %s |\n",
+				t.PostLoadReplicaSeconds, t.PostLoadDuration.Round(time.Millisecond), t.PostLoad, pricing.Currency)
+		}
+		markdown += fmt.Sprintf("| **Por 1000 Requisições** | - | - | **%.6f %s** |\n", t.Per1000Requests, pricing.Currency)
+
+		if c.PerRun.N > 1 {
+			markdown += "\n| Métrica | Média | Mediana | Desvio Padrão | Mínimo | Máximo | IC 95% |\n"
+			markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+			markdown += formatStatsRow("Custo por Execução ("+pricing.Currency+")", c.PerRun, "%.6f")
+			markdown += formatStatsRow("Custo por 1000 Requisições ("+pricing.Currency+")", c.Per1000Requests, "%.6f")
+		}
+
+		markdown += "\n| Execução | Janela | Requisições | Réplica-s | GB-s | vCPU-s | Custo | Depois da Carga | Por 1000 Requisições |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, e := range m.Executions {
+			cost := e.Cost
+			if cost == nil {
+				continue
+			}
+			markdown += fmt.Sprintf("| %d | %s | %d | %.2f | %.4f | %.4f | %.6f %s | %.6f %s (%s) | %.6f %s |\n",
+				e.Execution, cost.Duration.Round(time.Millisecond), cost.Requests, cost.ReplicaSeconds, cost.GBSeconds, cost.VCPUSeconds,
+				cost.Total, pricing.Currency, cost.PostLoad, pricing.Currency, cost.PostLoadDuration.Round(time.Millisecond),
+				cost.Per1000Requests, pricing.Currency)
+		}
+	}
+
+	if cs := m.ColdStart; cs != nil {
+		markdown += "\n## 3. Cold Start (sondagens com a função escalada para zero)\n\n"
+		markdown += fmt.Sprintf("Plataforma: **%s**, função: **%s**. Sondagens válidas: %d de %d.\n\n",
+			cs.Platform, cs.Function, cs.Latency.N, len(cs.Samples))
+		markdown += "| Métrica | Média | Mediana | Desvio Padrão | Mínimo | Máximo | IC 95% |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		markdown += formatStatsRow("Latência de Cold Start (s)", cs.Latency, "%.4f")
+
+		if len(cs.Percentiles) > 0 {
+			markdown += "\n| Percentil | Latência |\n"
+			markdown += "| :--- | :--- |\n"
+			for _, pct := range cs.Percentiles {
+				markdown += fmt.Sprintf("| p%g | %.4f s |\n", pct.Percentage*100, pct.Latency)
+			}
+		}
+
+		markdown += "\n| Sondagem | Espera até Zero Pods | Latência | Status | Pods Após | Observação |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, sample := range cs.Samples {
+			note := ""
+			switch {
+			case sample.Error != "":
+				note = sample.Error
+			case !sample.ScaledToZero:
+				note = "não escalou para zero"
+			}
+			markdown += fmt.Sprintf("| %d | %s | %.4f s | %d | %d | %s |\n",
+				sample.Iteration, sample.WaitedToZero.Round(time.Second), sample.Latency, sample.StatusCode, sample.PodsAfter, note)
+		}
+	}
+
+	if ka := m.KeepAlive; ka != nil {
+		markdown += "\n## 3. Janela de Keep-Alive (intervalos ociosos crescentes)\n\n"
+		markdown += fmt.Sprintf("Plataforma: **%s**, função: **%s**, estratégia: **%s**. Latência de referência com a instância quente: %.4f s.\n\n",
+			ka.Platform, ka.Function, ka.Strategy, ka.WarmLatency)
+		if ka.Found {
+			markdown += fmt.Sprintf("**Janela estimada: %s** (quente após %s sem tráfego, fria após %s).\n\n", ka.Window, ka.LastWarmGap, ka.FirstColdGap)
+		} else {
+			markdown += fmt.Sprintf("**Janela estimada: maior que %s** (nenhuma sondagem fria).\n\n", ka.LastWarmGap)
+		}
+		markdown += "| Intervalo Ocioso | Latência | Status | Pods Antes | Pods Após | Classificação |\n"
+		markdown += "| :--- | :--- | :--- | :--- | :--- | :--- |\n"
+		for _, probe := range ka.Probes {
+			class := "quente"
+			switch {
+			case probe.Error != "":
+				class = "erro: " + probe.Error
+			case probe.Cold:
+				class = "fria (" + probe.Reason + ")"
+			}
+			markdown += fmt.Sprintf("| %s | %.4f s | %d | %s | %s | %s |\n",
+				probe.Gap, probe.Latency, probe.StatusCode, formatPodCount(probe.PodsBefore), formatPodCount(probe.PodsAfter), class)
+		}
+	}
+
 	// Nota sobre Warm Start e Tráfego de Rede
 	markdown += "\n## 4. Notas Adicionais\n\n"
 	markdown += "A métrica de **Tempo de Inicialização** reportada acima é o **Cold Start ou Warm Start** (tempo até o container estar `running` após o início do benchmark).\n\n"
 
 	return markdown
 }
+
+// formatCooldown descreve a pausa feita antes de uma execução
+func formatCooldown(c *heyexec.CooldownResult) string {
+	switch {
+	case c == nil:
+		return "-"
+	case c.Error != "":
+		return fmt.Sprintf("%s, %s (falha: %s)", c.Policy, c.Waited.Round(time.Second), c.Error)
+	case !c.Reached:
+		return fmt.Sprintf("%s, %s (timeout)", c.Policy, c.Waited.Round(time.Second))
+	}
+	return fmt.Sprintf("%s, %s", c.Policy, c.Waited.Round(time.Second))
+}
+
+// formatPhase formata a duração de uma fase, ou N/A quando algum instante é desconhecido
+func formatPhase(from, to time.Time) string {
+	if from.IsZero() || to.IsZero() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.3f s", max(to.Sub(from), 0).Seconds())
+}
+
+// hasSnapshots indica se alguma execução tem snapshots do cluster
+func hasSnapshots(executions []metrics.ExecutionMetrics) bool {
+	for _, e := range executions {
+		if e.Snapshot != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasClusters indica se alguma execução tem métricas do cluster da sua janela
+func hasClusters(executions []metrics.ExecutionMetrics) bool {
+	for _, e := range executions {
+		if e.Cluster != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAutoscaling indica se alguma execução tem a linha do tempo do autoscaling
+func hasAutoscaling(executions []metrics.ExecutionMetrics) bool {
+	for _, e := range executions {
+		if e.Autoscaling != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// formatDeclared formata um recurso declarado na especificação, ou "não declarado"
+func formatDeclared(value float64, format func(float64) string) string {
+	if value <= 0 {
+		return "não declarado"
+	}
+	return format(value)
+}
+
+// formatUtilization formata a utilização relativa à especificação, ou "N/A" quando desconhecida
+func formatUtilization(u float64) string {
+	if u <= 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", u*100)
+}
+
+// formatPodNames lista os pods separados por vírgula, ou "-" quando não há nenhum
+func formatPodNames(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// signOf retorna o sinal de uma variação, para prefixar valores formatados sem sinal
+func signOf(v float64) string {
+	if v < 0 {
+		return "-"
+	}
+	return "+"
+}
+
+// formatPodCount formata uma contagem de pods, que é -1 quando não estava disponível
+func formatPodCount(count int) string {
+	if count < 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d", count)
+}
+
+// formatStatsRow formata uma linha da tabela de estatísticas entre execuções
+func formatStatsRow(name string, s metrics.Stats, valueFormat string) string {
+	f := func(v float64) string {
+		return fmt.Sprintf(valueFormat, v)
+	}
+	return fmt.Sprintf("| %s | %s | %s | %s | %s | %s | [%s, %s] |\n",
+		name, f(s.Mean), f(s.Median), f(s.StdDev), f(s.Min), f(s.Max), f(s.CI95Low), f(s.CI95High))
+}